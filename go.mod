@@ -1,7 +1,9 @@
 module github.com/ryndaniels/terraform-provider-xray
 
 require (
+	github.com/agext/levenshtein v1.2.2
 	github.com/atlassian/go-artifactory/v2 v2.3.0
+	github.com/hashicorp/go-multierror v1.0.0
 	github.com/hashicorp/terraform v0.12.29
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.3.0
 	github.com/stretchr/testify v1.5.1
@@ -0,0 +1,72 @@
+package jfrogxray
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/atlassian/go-artifactory/v2/artifactory/client"
+	"github.com/xero-oss/go-xray/xray"
+)
+
+// Xray scans in the background, so the provider polls for scan results this often
+var scanPollInterval = 10 * time.Second
+
+// xrayClient is what the provider hands to every resource. It embeds the go-xray API and keeps the HTTP client
+// go-xray is built on, for the endpoints and fields go-xray doesn't model yet.
+type xrayClient struct {
+	*xray.Xray
+	client *client.Client
+
+	// uploads streams request bodies from disk. The go-artifactory transports read every request body into memory
+	// to checksum it, so this client authenticates its requests itself.
+	uploads *client.Client
+}
+
+// newXrayClient builds the client on httpClient, and uses authenticate to add the same credentials to uploads
+func newXrayClient(baseURL string, httpClient *http.Client, authenticate func(*http.Request)) (*xrayClient, error) {
+	rt, err := xray.NewClient(baseURL, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.NewClient(baseURL, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	uploads, err := client.NewClient(baseURL, &http.Client{Transport: &authTransport{authenticate, http.DefaultTransport}})
+	if err != nil {
+		return nil, err
+	}
+	return &xrayClient{Xray: rt, client: c, uploads: uploads}, nil
+}
+
+// authTransport adds credentials to requests without touching their body
+type authTransport struct {
+	authenticate func(*http.Request)
+	transport    http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper mustn't change the request it's given, so the headers are set on a copy
+	req = req.Clone(req.Context())
+	t.authenticate(req)
+	return t.transport.RoundTrip(req)
+}
+
+// doJSON sends body (if any) as JSON and decodes the JSON response into v (if given), in the same way go-xray does
+func (c *xrayClient) doJSON(ctx context.Context, method, path string, body, v interface{}) (*http.Response, error) {
+	var req *http.Request
+	var err error
+	if body == nil {
+		// NewJSONEncodedRequest would pass a nil *bytes.Buffer on as a non-nil io.Reader
+		req, err = c.client.NewRequest(method, path, nil)
+	} else {
+		req, err = c.client.NewJSONEncodedRequest(method, path, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", client.MediaTypeJson)
+
+	return c.client.Do(ctx, req, v)
+}
@@ -0,0 +1,54 @@
+package jfrogxray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type xrayBuildScanRequest struct {
+	BuildName   string `json:"build_name"`
+	BuildNumber string `json:"build_number"`
+	Project     string `json:"project,omitempty"`
+	Rescan      bool   `json:"rescan,omitempty"`
+}
+
+// Until the scan is done, the result only has the status, or just an info message
+type xrayBuildScanResult struct {
+	Info           string               `json:"info"`
+	BuildName      string               `json:"build_name"`
+	BuildNumber    string               `json:"build_number"`
+	Status         string               `json:"status"`
+	MoreDetailsURL string               `json:"more_details_url"`
+	FailBuild      bool                 `json:"fail_build"`
+	Violations     []xrayBuildViolation `json:"violations"`
+}
+
+type xrayBuildViolation struct {
+	Type              string                `json:"type"`
+	Severity          string                `json:"severity"`
+	Summary           string                `json:"summary"`
+	Description       string                `json:"description"`
+	IssueID           string                `json:"issue_id"`
+	WatchName         string                `json:"watch_name"`
+	Created           string                `json:"created"`
+	FixVersions       []string              `json:"fix_versions"`
+	ImpactedArtifacts []string              `json:"impacted_artifacts"`
+	Policies          []xrayViolationPolicy `json:"policies"`
+}
+
+func (c *xrayClient) scanBuild(ctx context.Context, req xrayBuildScanRequest) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPost, "/api/v2/ci/build", req, nil)
+}
+
+func (c *xrayClient) getBuildScanResult(ctx context.Context, name, number, project string) (*xrayBuildScanResult, *http.Response, error) {
+	path := fmt.Sprintf("/api/v2/ci/build/%s/%s", url.PathEscape(name), url.PathEscape(number))
+	if project != "" {
+		path += "?projectKey=" + url.QueryEscape(project)
+	}
+
+	result := new(xrayBuildScanResult)
+	resp, err := c.doJSON(ctx, http.MethodGet, path, nil, result)
+	return result, resp, err
+}
@@ -0,0 +1,71 @@
+package jfrogxray
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// The daily database sync runs at this time of day, e.g. "23:00". Only online instances sync.
+type xrayDBSyncTime struct {
+	DBSyncUpdatesTime string `json:"db_sync_updates_time"`
+}
+
+// xrayDBSyncSettings controls how Xray gets database updates. Fields that are left out of an update keep their
+// current value.
+type xrayDBSyncSettings struct {
+	OfflineMode              *bool `json:"offline_mode,omitempty"`
+	UseProxy                 *bool `json:"use_proxy,omitempty"`
+	ConnectionTimeoutSeconds *int  `json:"connection_timeout_seconds,omitempty"`
+}
+
+// xrayOfflineDBUpdate is the import of an offline update bundle, which Xray does in the background
+type xrayOfflineDBUpdate struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+func (c *xrayClient) getDBSyncTime(ctx context.Context) (*xrayDBSyncTime, *http.Response, error) {
+	syncTime := new(xrayDBSyncTime)
+	resp, err := c.doJSON(ctx, http.MethodGet, "/api/v1/configuration/dbsync/time", nil, syncTime)
+	return syncTime, resp, err
+}
+
+func (c *xrayClient) updateDBSyncTime(ctx context.Context, syncTime *xrayDBSyncTime) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPut, "/api/v1/configuration/dbsync/time", syncTime, nil)
+}
+
+func (c *xrayClient) getDBSyncSettings(ctx context.Context) (*xrayDBSyncSettings, *http.Response, error) {
+	settings := new(xrayDBSyncSettings)
+	resp, err := c.doJSON(ctx, http.MethodGet, "/api/v1/configuration/dbsync", nil, settings)
+	return settings, resp, err
+}
+
+func (c *xrayClient) updateDBSyncSettings(ctx context.Context, settings *xrayDBSyncSettings) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPut, "/api/v1/configuration/dbsync", settings, nil)
+}
+
+// uploadOfflineDBUpdate streams size bytes of an offline update bundle to Xray as is, and returns the import Xray
+// started for it. Bundles can be several gigabytes, so they are never held in memory.
+func (c *xrayClient) uploadOfflineDBUpdate(ctx context.Context, bundle io.Reader, size int64) (*xrayOfflineDBUpdate, *http.Response, error) {
+	req, err := c.uploads.NewRequest(http.MethodPost, "/api/v1/updates/offline", bundle)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Accept", "application/json")
+
+	update := new(xrayOfflineDBUpdate)
+	resp, err := c.uploads.Do(ctx, req, update)
+	return update, resp, err
+}
+
+func (c *xrayClient) getOfflineDBUpdate(ctx context.Context, id string) (*xrayOfflineDBUpdate, *http.Response, error) {
+	update := new(xrayOfflineDBUpdate)
+	resp, err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/api/v1/updates/offline/%s", url.PathEscape(id)), nil, update)
+	return update, resp, err
+}
@@ -0,0 +1,155 @@
+package jfrogxray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// xrayIssueEvent is an issue in the Xray database, as read and written by the issue events API. Custom issues are
+// created through the same API.
+type xrayIssueEvent struct {
+	ID          string                    `json:"id"`
+	Type        string                    `json:"type"`
+	Provider    string                    `json:"provider"`
+	PackageType string                    `json:"package_type"`
+	Severity    string                    `json:"severity"`
+	Summary     string                    `json:"summary"`
+	Description string                    `json:"description"`
+	Components  []xrayIssueEventComponent `json:"components"`
+	CVEs        []xrayIssueEventCVE       `json:"cves,omitempty"`
+	Sources     []xrayIssueEventSource    `json:"sources,omitempty"`
+}
+
+type xrayIssueEventComponent struct {
+	ID                 string   `json:"id"`
+	VulnerableVersions []string `json:"vulnerable_versions"`
+	FixedVersions      []string `json:"fixed_versions,omitempty"`
+}
+
+// CVSS scores come with their vector, e.g. "10.0/CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"
+type xrayIssueEventCVE struct {
+	CVE    string `json:"cve"`
+	CVSSV2 string `json:"cvss_v2,omitempty"`
+	CVSSV3 string `json:"cvss_v3,omitempty"`
+}
+
+type xrayIssueEventSource struct {
+	SourceID string `json:"source_id,omitempty"`
+	Name     string `json:"name"`
+	URL      string `json:"url,omitempty"`
+}
+
+// splitCVSS splits a CVSS score from its vector
+func splitCVSS(cvss string) (score, vector string) {
+	parts := strings.SplitN(cvss, "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+func (c *xrayClient) getIssueEvent(ctx context.Context, id string) (*xrayIssueEvent, *http.Response, error) {
+	event := new(xrayIssueEvent)
+	resp, err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/api/v2/events/%s", url.PathEscape(id)), nil, event)
+	return event, resp, err
+}
+
+type xrayCVESearchRequest struct {
+	CVEs []string `json:"cves"`
+}
+
+// xrayCVESearchResult lists the components that a CVE affects
+type xrayCVESearchResult struct {
+	CVE        string               `json:"cve"`
+	Components []xrayCVESearchMatch `json:"components"`
+}
+
+type xrayCVESearchMatch struct {
+	Name        string `json:"name"`
+	PackageType string `json:"package_type"`
+	Version     string `json:"version"`
+}
+
+// componentIDPrefixes maps Xray package types to the package types of Xray component IDs
+var componentIDPrefixes = map[string]string{
+	"alpine":      "alpine",
+	"cargo":       "cargo",
+	"cocoapods":   "cocoapods",
+	"composer":    "composer",
+	"conan":       "conan",
+	"conda":       "conda",
+	"cran":        "cran",
+	"debian":      "deb",
+	"docker":      "docker",
+	"gems":        "gem",
+	"generic":     "generic",
+	"go":          "go",
+	"huggingface": "huggingfaceml",
+	"maven":       "gav",
+	"npm":         "npm",
+	"nuget":       "nuget",
+	"pypi":        "pypi",
+	"rpm":         "rpm",
+	"terraform":   "terraform",
+}
+
+func (c *xrayClient) searchComponentsByCVE(ctx context.Context, cve string) ([]xrayCVESearchResult, *http.Response, error) {
+	var results []xrayCVESearchResult
+	resp, err := c.doJSON(ctx, http.MethodPost, "/api/v1/component/searchByCves", xrayCVESearchRequest{CVEs: []string{cve}}, &results)
+	return results, resp, err
+}
+
+// resolveCVE finds the Xray issue of a CVE. The events API only takes Xray issue IDs, so the CVE is looked up
+// through the components it affects, whose summaries name the issue. It returns "" if Xray doesn't know the CVE.
+func (c *xrayClient) resolveCVE(ctx context.Context, cve string) (string, error) {
+	results, _, err := c.searchComponentsByCVE(ctx, cve)
+	if err != nil {
+		return "", err
+	}
+
+	componentIDs := []string{}
+	for _, result := range results {
+		if !strings.EqualFold(result.CVE, cve) {
+			continue
+		}
+		for _, m := range result.Components {
+			prefix, ok := componentIDPrefixes[strings.ToLower(m.PackageType)]
+			if ok && m.Version != "" {
+				componentIDs = append(componentIDs, fmt.Sprintf("%s://%s:%s", prefix, m.Name, m.Version))
+			}
+		}
+	}
+	if len(componentIDs) == 0 {
+		return "", nil
+	}
+
+	summary, _, err := c.getComponentSummary(ctx, componentIDs)
+	if err != nil {
+		return "", err
+	}
+	for _, artifact := range summary.Artifacts {
+		for _, issue := range artifact.Issues {
+			for _, issueCVE := range issue.CVEs {
+				if strings.EqualFold(issueCVE.CVE, cve) {
+					return issue.IssueID, nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+func (c *xrayClient) createIssueEvent(ctx context.Context, event *xrayIssueEvent) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPost, "/api/v1/events", event, nil)
+}
+
+func (c *xrayClient) updateIssueEvent(ctx context.Context, id string, event *xrayIssueEvent) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPut, fmt.Sprintf("/api/v1/events/%s", url.PathEscape(id)), event, nil)
+}
+
+func (c *xrayClient) deleteIssueEvent(ctx context.Context, id string) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/events/%s", url.PathEscape(id)), nil, nil)
+}
@@ -0,0 +1,80 @@
+package jfrogxray
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// xrayGraphNode is a component and the components it depends on
+type xrayGraphNode struct {
+	ComponentID string           `json:"component_id"`
+	Nodes       []*xrayGraphNode `json:"nodes,omitempty"`
+}
+
+type xrayGraphScanResponse struct {
+	ScanID string `json:"scan_id"`
+}
+
+// Until the scan is done, the result only has an info message
+type xrayGraphScanResult struct {
+	Info            string                 `json:"info"`
+	ScanID          string                 `json:"scan_id"`
+	Violations      []xrayGraphScanIssue   `json:"violations"`
+	Vulnerabilities []xrayGraphScanIssue   `json:"vulnerabilities"`
+	Licenses        []xrayGraphScanLicense `json:"licenses"`
+}
+
+type xrayGraphScanIssue struct {
+	IssueID    string                                 `json:"issue_id"`
+	Summary    string                                 `json:"summary"`
+	Severity   string                                 `json:"severity"`
+	Type       string                                 `json:"type"`
+	WatchName  string                                 `json:"watch_name"`
+	CVEs       []xrayGraphScanCVE                     `json:"cves"`
+	Components map[string]xrayGraphScanIssueComponent `json:"components"`
+}
+
+type xrayGraphScanCVE struct {
+	CVE         string `json:"cve"`
+	CVSSV2Score string `json:"cvss_v2_score"`
+	CVSSV3Score string `json:"cvss_v3_score"`
+}
+
+type xrayGraphScanIssueComponent struct {
+	FixedVersions []string `json:"fixed_versions"`
+}
+
+type xrayGraphScanLicense struct {
+	LicenseKey string                     `json:"license_key"`
+	Components map[string]json.RawMessage `json:"components"`
+}
+
+// scanGraph submits a dependency graph. Violations are only reported for the given watches or project.
+func (c *xrayClient) scanGraph(ctx context.Context, graph *xrayGraphNode, watches []string, project string) (*xrayGraphScanResponse, *http.Response, error) {
+	query := url.Values{}
+	for _, w := range watches {
+		query.Add("watch", w)
+	}
+	if project != "" {
+		query.Set("project", project)
+	}
+	path := "/api/v1/scan/graph"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	scan := new(xrayGraphScanResponse)
+	resp, err := c.doJSON(ctx, http.MethodPost, path, graph, scan)
+	return scan, resp, err
+}
+
+func (c *xrayClient) getGraphScanResult(ctx context.Context, scanID string) (*xrayGraphScanResult, *http.Response, error) {
+	path := fmt.Sprintf("/api/v1/scan/graph/%s?include_vulnerabilities=true&include_licenses=true", url.PathEscape(scanID))
+
+	result := new(xrayGraphScanResult)
+	resp, err := c.doJSON(ctx, http.MethodGet, path, nil, result)
+	return result, resp, err
+}
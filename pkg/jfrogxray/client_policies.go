@@ -0,0 +1,81 @@
+package jfrogxray
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	v1 "github.com/xero-oss/go-xray/xray/v1"
+)
+
+// go-xray only models the policy fields Xray had when it was written. These types embed its structs and add the
+// newer fields, replacing the nested structs so that the additions are sent and read at every level.
+
+type xrayPolicy struct {
+	v1.Policy
+	Rules *[]xrayPolicyRule `json:"rules,omitempty"`
+
+	extraJSON string          // merged into the request body, see marshalWithExtraJSON
+	response  json.RawMessage // the body the policy was read from
+}
+
+func (p xrayPolicy) MarshalJSON() ([]byte, error) {
+	type plain xrayPolicy // without the MarshalJSON method
+	return marshalWithExtraJSON(plain(p), nil, p.extraJSON)
+}
+
+type xrayPolicyRule struct {
+	v1.PolicyRule
+	Criteria *xrayPolicyRuleCriteria `json:"criteria,omitempty"`
+	Actions  *xrayPolicyRuleActions  `json:"actions,omitempty"`
+}
+
+type xrayPolicyRuleCriteria struct {
+	v1.PolicyRuleCriteria
+	VulnerabilityIDs       *[]string            `json:"vulnerability_ids,omitempty"`
+	PackageType            *string              `json:"package_type,omitempty"`
+	PackageName            *string              `json:"package_name,omitempty"`
+	PackageVersions        *[]string            `json:"package_versions,omitempty"`
+	FixVersionDependant    *bool                `json:"fix_version_dependant,omitempty"`
+	ApplicableCVEsOnly     *bool                `json:"applicable_cves_only,omitempty"`
+	Exposures              *xrayPolicyExposures `json:"exposures,omitempty"`
+	MaliciousPackage       *bool                `json:"malicious_package,omitempty"`
+	MultiLicensePermissive *bool                `json:"multi_license_permissive,omitempty"`
+}
+
+type xrayPolicyExposures struct {
+	MinSeverity  *string `json:"min_severity,omitempty"`
+	Secrets      *bool   `json:"secrets,omitempty"`
+	Applications *bool   `json:"applications,omitempty"`
+	Services     *bool   `json:"services,omitempty"`
+	IaC          *bool   `json:"iac,omitempty"`
+}
+
+type xrayPolicyRuleActions struct {
+	v1.PolicyRuleActions
+	NotifyWatchRecipients          *bool `json:"notify_watch_recipients,omitempty"`
+	NotifyDeployer                 *bool `json:"notify_deployer,omitempty"`
+	CreateTicketEnabled            *bool `json:"create_ticket_enabled,omitempty"`
+	BuildFailureGracePeriodInDays  *int  `json:"build_failure_grace_period_in_days,omitempty"`
+	BlockReleaseBundleDistribution *bool `json:"block_release_bundle_distribution,omitempty"`
+	BlockReleaseBundlePromotion    *bool `json:"block_release_bundle_promotion,omitempty"`
+	FailPullRequest                *bool `json:"fail_pull_request,omitempty"`
+}
+
+func (c *xrayClient) getPolicy(ctx context.Context, name string) (*xrayPolicy, *http.Response, error) {
+	policy := new(xrayPolicy)
+	resp, err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/api/v1/policies/%s", name), nil, &policy.response)
+	if err == nil {
+		err = json.Unmarshal(policy.response, policy)
+	}
+	return policy, resp, err
+}
+
+func (c *xrayClient) createPolicy(ctx context.Context, policy *xrayPolicy) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPost, "/api/v1/policies", policy, nil)
+}
+
+func (c *xrayClient) updatePolicy(ctx context.Context, name string, policy *xrayPolicy) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPut, fmt.Sprintf("/api/v1/policies/%s", name), policy, nil)
+}
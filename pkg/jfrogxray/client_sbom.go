@@ -0,0 +1,56 @@
+package jfrogxray
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// xrayExportRequest asks Xray for the SBOM of a component. Only one of the SPDX and CycloneDX formats is requested.
+type xrayExportRequest struct {
+	PackageType     string `json:"package_type"`
+	ComponentName   string `json:"component_name"`
+	Path            string `json:"path"`
+	Security        bool   `json:"security"`
+	License         bool   `json:"license"`
+	SPDX            bool   `json:"spdx"`
+	SPDXFormat      string `json:"spdx_format,omitempty"`
+	CycloneDX       bool   `json:"cyclonedx"`
+	CycloneDXFormat string `json:"cyclonedx_format,omitempty"`
+	VEX             bool   `json:"vex"`
+}
+
+// exportSBOM returns the SBOM document. Xray sends it as the only file of a zip archive.
+func (c *xrayClient) exportSBOM(ctx context.Context, req xrayExportRequest) ([]byte, *http.Response, error) {
+	// Unlike doJSON, this doesn't ask for a JSON response
+	r, err := c.client.NewJSONEncodedRequest(http.MethodPost, "/api/v2/component/exportDetails", req)
+	if err != nil {
+		return nil, nil, err
+	}
+	body := new(bytes.Buffer)
+	resp, err := c.client.Do(ctx, r, body)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(body.Bytes()), int64(body.Len()))
+	if err != nil {
+		return nil, resp, fmt.Errorf("error reading the SBOM archive from Xray: %s", err)
+	}
+	for _, f := range archive.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, resp, err
+		}
+		defer rc.Close()
+		doc, err := ioutil.ReadAll(rc)
+		return doc, resp, err
+	}
+	return nil, resp, fmt.Errorf("the SBOM archive from Xray is empty")
+}
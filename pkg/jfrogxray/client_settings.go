@@ -0,0 +1,26 @@
+package jfrogxray
+
+import (
+	"context"
+	"net/http"
+)
+
+// xraySettings are Xray's basic settings. Fields that are left out of an update keep their current value.
+type xraySettings struct {
+	Enabled                            *bool `json:"enabled,omitempty"`
+	AllowBlocked                       *bool `json:"allowBlocked,omitempty"`
+	AllowWhenUnavailable               *bool `json:"allowWhenUnavailable,omitempty"`
+	BlockUnscannedTimeoutSeconds       *int  `json:"blockUnscannedTimeoutSeconds,omitempty"`
+	BlockUnfinishedScansTimeoutSeconds *int  `json:"blockUnfinishedScansTimeoutSeconds,omitempty"`
+	BuildRetentionDays                 *int  `json:"buildRetentionDays,omitempty"`
+}
+
+func (c *xrayClient) getSettings(ctx context.Context) (*xraySettings, *http.Response, error) {
+	settings := new(xraySettings)
+	resp, err := c.doJSON(ctx, http.MethodGet, "/api/v1/xraySettings", nil, settings)
+	return settings, resp, err
+}
+
+func (c *xrayClient) updateSettings(ctx context.Context, settings *xraySettings) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPut, "/api/v1/xraySettings", settings, nil)
+}
@@ -0,0 +1,139 @@
+package jfrogxray
+
+import (
+	"context"
+	"net/http"
+)
+
+type xrayComponentSummaryRequest struct {
+	ComponentDetails []xrayComponentDetails `json:"component_details"`
+}
+
+type xrayComponentDetails struct {
+	ComponentID string `json:"component_id"`
+}
+
+// Paths start with the name of the Artifactory instance, which is "default" unless Xray is connected to several
+type xrayArtifactSummaryRequest struct {
+	Paths     []string `json:"paths,omitempty"`
+	Checksums []string `json:"checksums,omitempty"`
+}
+
+type xrayArtifactStatusRequest struct {
+	Repo string `json:"repo"`
+	Path string `json:"path"`
+}
+
+type xrayArtifactStatusResponse struct {
+	Overall struct {
+		Status string `json:"status"`
+		Time   string `json:"time"`
+	} `json:"overall"`
+}
+
+// The summary APIs for components and artifacts answer in the same format
+type xraySummaryResponse struct {
+	Artifacts []xraySummaryArtifact `json:"artifacts"`
+	Errors    []xraySummaryError    `json:"errors"`
+}
+
+type xraySummaryArtifact struct {
+	General  xraySummaryGeneral   `json:"general"`
+	Issues   []xraySummaryIssue   `json:"issues"`
+	Licenses []xraySummaryLicense `json:"licenses"`
+}
+
+type xraySummaryGeneral struct {
+	ComponentID string `json:"component_id"`
+	Name        string `json:"name"`
+	PkgType     string `json:"pkg_type"`
+	Path        string `json:"path"`
+	SHA256      string `json:"sha256"`
+}
+
+type xraySummaryIssue struct {
+	IssueID     string                      `json:"issue_id"`
+	Summary     string                      `json:"summary"`
+	Description string                      `json:"description"`
+	IssueType   string                      `json:"issue_type"`
+	Severity    string                      `json:"severity"`
+	Provider    string                      `json:"provider"`
+	Created     string                      `json:"created"`
+	CVEs        []xraySummaryCVE            `json:"cves"`
+	Components  []xraySummaryIssueComponent `json:"components"`
+}
+
+type xraySummaryCVE struct {
+	CVE    string `json:"cve"`
+	CVSSV2 string `json:"cvss_v2"`
+	CVSSV3 string `json:"cvss_v3"`
+}
+
+type xraySummaryIssueComponent struct {
+	ComponentID   string   `json:"component_id"`
+	FixedVersions []string `json:"fixed_versions"`
+}
+
+type xraySummaryLicense struct {
+	Name        string   `json:"name"`
+	FullName    string   `json:"full_name"`
+	MoreInfoURL []string `json:"more_info_url"`
+	Components  []string `json:"components"`
+}
+
+type xraySummaryError struct {
+	Error      string `json:"error"`
+	Identifier string `json:"identifier"`
+}
+
+type xrayOperationalRiskRequest struct {
+	Components []xrayComponentDetails `json:"components"`
+}
+
+type xrayOperationalRiskResponse struct {
+	Components []xrayOperationalRisk `json:"components"`
+}
+
+type xrayOperationalRisk struct {
+	ComponentID   string `json:"component_id"`
+	Risk          string `json:"risk"`
+	RiskReason    string `json:"risk_reason"`
+	IsEOL         bool   `json:"is_eol"`
+	EOLMessage    string `json:"eol_message"`
+	LatestVersion string `json:"latest_version"`
+	NewerVersions int    `json:"newer_versions"`
+}
+
+func (c *xrayClient) getComponentSummary(ctx context.Context, componentIDs []string) (*xraySummaryResponse, *http.Response, error) {
+	req := xrayComponentSummaryRequest{}
+	for _, id := range componentIDs {
+		req.ComponentDetails = append(req.ComponentDetails, xrayComponentDetails{ComponentID: id})
+	}
+
+	summary := new(xraySummaryResponse)
+	resp, err := c.doJSON(ctx, http.MethodPost, "/api/v1/summary/component", req, summary)
+	return summary, resp, err
+}
+
+func (c *xrayClient) getArtifactSummary(ctx context.Context, req xrayArtifactSummaryRequest) (*xraySummaryResponse, *http.Response, error) {
+	summary := new(xraySummaryResponse)
+	resp, err := c.doJSON(ctx, http.MethodPost, "/api/v1/summary/artifact", req, summary)
+	return summary, resp, err
+}
+
+func (c *xrayClient) getArtifactScanStatus(ctx context.Context, repo, path string) (*xrayArtifactStatusResponse, *http.Response, error) {
+	status := new(xrayArtifactStatusResponse)
+	resp, err := c.doJSON(ctx, http.MethodPost, "/api/v1/artifact/status", xrayArtifactStatusRequest{Repo: repo, Path: path}, status)
+	return status, resp, err
+}
+
+func (c *xrayClient) getOperationalRisk(ctx context.Context, componentIDs []string) (*xrayOperationalRiskResponse, *http.Response, error) {
+	req := xrayOperationalRiskRequest{}
+	for _, id := range componentIDs {
+		req.Components = append(req.Components, xrayComponentDetails{ComponentID: id})
+	}
+
+	risks := new(xrayOperationalRiskResponse)
+	resp, err := c.doJSON(ctx, http.MethodPost, "/api/v1/operational_risk/component", req, risks)
+	return risks, resp, err
+}
@@ -0,0 +1,101 @@
+package jfrogxray
+
+import (
+	"context"
+	"net/http"
+)
+
+// Xray returns at most this many violations per request
+const violationsPageSize = 100
+
+type xrayViolationsRequest struct {
+	Filters    xrayViolationsFilters `json:"filters"`
+	Pagination xrayPagination        `json:"pagination"`
+}
+
+type xrayViolationsFilters struct {
+	WatchName     string                  `json:"watch_name,omitempty"`
+	ViolationType string                  `json:"violation_type,omitempty"`
+	MinSeverity   string                  `json:"min_severity,omitempty"`
+	CreatedFrom   string                  `json:"created_from,omitempty"`
+	CreatedUntil  string                  `json:"created_until,omitempty"`
+	Resources     *xrayViolationResources `json:"resources,omitempty"`
+}
+
+type xrayViolationResources struct {
+	Artifacts      []xrayViolationArtifact      `json:"artifacts,omitempty"`
+	Builds         []xrayViolationBuild         `json:"builds,omitempty"`
+	ReleaseBundles []xrayViolationReleaseBundle `json:"release_bundles,omitempty"`
+}
+
+type xrayViolationArtifact struct {
+	Repo string `json:"repo"`
+	Path string `json:"path"`
+}
+
+type xrayViolationBuild struct {
+	Name   string `json:"name"`
+	Number string `json:"number,omitempty"`
+}
+
+type xrayViolationReleaseBundle struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// Offset is the number of the page, starting at 1
+type xrayPagination struct {
+	OrderBy   string `json:"order_by,omitempty"`
+	Direction string `json:"direction,omitempty"`
+	Limit     int    `json:"limit"`
+	Offset    int    `json:"offset"`
+}
+
+type xrayViolationsResponse struct {
+	Total      int             `json:"total_violations"`
+	Violations []xrayViolation `json:"violations"`
+}
+
+type xrayViolation struct {
+	ID                  string                `json:"violation_id"`
+	Type                string                `json:"type"`
+	Severity            string                `json:"severity"`
+	Description         string                `json:"description"`
+	IssueID             string                `json:"issue_id"`
+	WatchName           string                `json:"watch_name"`
+	Created             string                `json:"created"`
+	ViolationDetailsURL string                `json:"violation_details_url"`
+	InfectedComponents  []string              `json:"infected_components"`
+	ImpactedArtifacts   []string              `json:"impacted_artifacts"`
+	MatchedPolicies     []xrayViolationPolicy `json:"matched_policies"`
+}
+
+type xrayViolationPolicy struct {
+	Policy     string `json:"policy"`
+	Rule       string `json:"rule"`
+	IsBlocking bool   `json:"is_blocking"`
+}
+
+// searchViolations pages through every violation matching the filters, oldest first
+func (c *xrayClient) searchViolations(ctx context.Context, filters xrayViolationsFilters) ([]xrayViolation, *http.Response, error) {
+	req := xrayViolationsRequest{
+		Filters:    filters,
+		Pagination: xrayPagination{OrderBy: "created", Direction: "asc", Limit: violationsPageSize, Offset: 1},
+	}
+
+	violations := []xrayViolation{}
+	for {
+		page := xrayViolationsResponse{}
+		resp, err := c.doJSON(ctx, http.MethodPost, "/api/v1/violations", req, &page)
+		if err != nil {
+			return nil, resp, err
+		}
+		violations = append(violations, page.Violations...)
+
+		// Violations created while paging shift the pages, so stop at an empty page as well as at the total
+		if len(page.Violations) == 0 || len(violations) >= page.Total {
+			return violations, resp, nil
+		}
+		req.Pagination.Offset++
+	}
+}
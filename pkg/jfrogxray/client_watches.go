@@ -0,0 +1,40 @@
+package jfrogxray
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	v2 "github.com/xero-oss/go-xray/xray/v2"
+)
+
+// xrayWatch is a go-xray watch that keeps the body it was read from. When it's written back, the fields go-xray
+// doesn't model are sent back unchanged, rather than being dropped from the watch.
+type xrayWatch struct {
+	v2.Watch
+
+	extraJSON string          // merged into the request body, see marshalWithExtraJSON
+	response  json.RawMessage // the body the watch was read from
+}
+
+func (w xrayWatch) MarshalJSON() ([]byte, error) {
+	return marshalWithExtraJSON(w.Watch, w.response, w.extraJSON)
+}
+
+func (c *xrayClient) getWatch(ctx context.Context, name string) (*xrayWatch, *http.Response, error) {
+	watch := new(xrayWatch)
+	resp, err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/api/v2/watches/%s", name), nil, &watch.response)
+	if err == nil {
+		err = json.Unmarshal(watch.response, &watch.Watch)
+	}
+	return watch, resp, err
+}
+
+func (c *xrayClient) createWatch(ctx context.Context, watch *xrayWatch) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPost, "/api/v2/watches", watch, nil)
+}
+
+func (c *xrayClient) updateWatch(ctx context.Context, name string, watch *xrayWatch) (*http.Response, error) {
+	return c.doJSON(ctx, http.MethodPut, fmt.Sprintf("/api/v2/watches/%s", name), watch, nil)
+}
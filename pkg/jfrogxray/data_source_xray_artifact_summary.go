@@ -0,0 +1,202 @@
+package jfrogxray
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Scan states of an artifact that Xray is still working on, and those it won't change from
+var (
+	pendingScanStates = []string{"NOT_SCANNED", "PENDING", "IN_PROGRESS"}
+	doneScanStates    = []string{"DONE", "NOT_SUPPORTED"}
+)
+
+// Reads the issues and licenses Xray found in an artifact, optionally waiting for Xray to finish scanning it first,
+// so that a gate on a freshly pushed artifact doesn't pass just because the scan hasn't finished yet
+func dataSourceXrayArtifactSummary() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceXrayArtifactSummaryRead,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"checksum"},
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[^/]+/.+$`),
+					"must be the path of an artifact including the repository, e.g. docker-local/app/1.2.3/manifest.json",
+				),
+			},
+			"checksum": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"path"},
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[0-9a-fA-F]{64}$`),
+					"must be a SHA-256 checksum",
+				),
+			},
+			"wait_for_scan": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "10m",
+				ValidateFunc: validateDuration,
+			},
+
+			"scan_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"package_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"issues":   summaryIssuesSchema(),
+			"licenses": summaryLicensesSchema(),
+		},
+	}
+}
+
+func validateDuration(v interface{}, k string) (ws []string, es []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s must be a duration such as \"30s\" or \"10m\": %s", k, err))
+	}
+	return
+}
+
+// waitForScan polls refresh until it reports one of the done states, or the timeout expires
+func waitForScan(description string, timeout time.Duration, refresh resource.StateRefreshFunc) (interface{}, error) {
+	conf := &resource.StateChangeConf{
+		Pending:      pendingScanStates,
+		Target:       doneScanStates,
+		Refresh:      refresh,
+		Timeout:      timeout,
+		PollInterval: scanPollInterval,
+	}
+	result, err := conf.WaitForState()
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for Xray to scan %s: %s", description, err)
+	}
+	return result, nil
+}
+
+func dataSourceXrayArtifactSummaryRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+	ctx := context.Background()
+
+	path := d.Get("path").(string)
+	checksum := d.Get("checksum").(string)
+	if path == "" && checksum == "" {
+		return fmt.Errorf("one of path or checksum must be set")
+	}
+
+	timeout, err := time.ParseDuration(d.Get("timeout").(string))
+	if err != nil {
+		return err
+	}
+
+	req := xrayArtifactSummaryRequest{}
+	if path != "" {
+		req.Paths = []string{"default/" + path}
+
+		// Only artifacts in Artifactory have a scan status, so it is only known by path
+		parts := strings.SplitN(path, "/", 2)
+		refresh := func() (interface{}, string, error) {
+			status, _, err := c.getArtifactScanStatus(ctx, parts[0], parts[1])
+			if err != nil {
+				return nil, "", err
+			}
+			if status.Overall.Status == "FAILED" {
+				return nil, "", fmt.Errorf("Xray failed to scan %s", path)
+			}
+			return status, status.Overall.Status, nil
+		}
+
+		var status interface{}
+		if d.Get("wait_for_scan").(bool) {
+			status, err = waitForScan(path, timeout, refresh)
+		} else {
+			status, _, err = refresh()
+		}
+		if err != nil {
+			return err
+		}
+		if err := d.Set("scan_status", status.(*xrayArtifactStatusResponse).Overall.Status); err != nil {
+			return err
+		}
+	} else {
+		req.Checksums = []string{checksum}
+
+		// Xray doesn't know an artifact by its checksum until it has indexed it
+		if d.Get("wait_for_scan").(bool) {
+			_, err := waitForScan(checksum, timeout, func() (interface{}, string, error) {
+				summary, _, err := c.getArtifactSummary(ctx, req)
+				if err != nil {
+					return nil, "", err
+				}
+				if len(summary.Errors) > 0 || len(summary.Artifacts) == 0 {
+					return summary, "NOT_SCANNED", nil
+				}
+				return summary, "DONE", nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	summary, _, err := c.getArtifactSummary(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := summaryErrors(summary.Errors); err != nil {
+		return err
+	}
+	if len(summary.Artifacts) != 1 {
+		return fmt.Errorf("expected Xray to return the summary of 1 artifact, got %d", len(summary.Artifacts))
+	}
+	artifact := summary.Artifacts[0]
+
+	if err := d.Set("name", artifact.General.Name); err != nil {
+		return err
+	}
+	if err := d.Set("package_type", artifact.General.PkgType); err != nil {
+		return err
+	}
+	if err := d.Set("sha256", artifact.General.SHA256); err != nil {
+		return err
+	}
+	if err := d.Set("issues", flattenSummaryIssues(artifact.Issues)); err != nil {
+		return err
+	}
+	if err := d.Set("licenses", flattenSummaryLicenses(artifact.Licenses)); err != nil {
+		return err
+	}
+
+	if path != "" {
+		d.SetId(path)
+	} else {
+		d.SetId(checksum)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package jfrogxray
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+const testChecksum = "0d2c3b1e4b3f5a6c7d8e9f00112233445566778899aabbccddeeff0011223344"
+
+func TestDataSourceXrayArtifactSummary(t *testing.T) {
+	fastScanPolling(t)
+	server := newTestXrayServer(t)
+	server.artifacts["default/docker-local/app/1.2.3/manifest.json"] = testComponentSummary("docker://app:1.2.3", "app", "Critical")
+	server.artifacts["default/docker-local/app/1.2.4/manifest.json"] = testComponentSummary("docker://app:1.2.4", "app", "")
+	server.artifacts[testChecksum] = testComponentSummary("docker://app:1.2.4", "app", "")
+	server.scanStatuses["docker-local/app/1.2.3/manifest.json"] = []string{"NOT_SCANNED", "IN_PROGRESS", "DONE"}
+	server.scanStatuses["docker-local/app/1.2.4/manifest.json"] = []string{"IN_PROGRESS"}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+data "xray_artifact_summary" "scanned" {
+	path          = "docker-local/app/1.2.3/manifest.json"
+	wait_for_scan = true
+}
+
+data "xray_artifact_summary" "in_progress" {
+	path = "docker-local/app/1.2.4/manifest.json"
+}
+
+data "xray_artifact_summary" "checksum" {
+	checksum = "` + testChecksum + `"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.xray_artifact_summary.scanned", "scan_status", "DONE"),
+					resource.TestCheckResourceAttr("data.xray_artifact_summary.scanned", "issues.#", "1"),
+					resource.TestCheckResourceAttr("data.xray_artifact_summary.scanned", "issues.0.severity", "Critical"),
+					resource.TestCheckResourceAttr("data.xray_artifact_summary.scanned", "licenses.0.name", "MIT"),
+					resource.TestCheckResourceAttr("data.xray_artifact_summary.in_progress", "scan_status", "IN_PROGRESS"),
+					resource.TestCheckResourceAttr("data.xray_artifact_summary.in_progress", "issues.#", "0"),
+					resource.TestCheckResourceAttr("data.xray_artifact_summary.checksum", "id", testChecksum),
+					resource.TestCheckNoResourceAttr("data.xray_artifact_summary.checksum", "scan_status"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceXrayArtifactSummary_errors(t *testing.T) {
+	fastScanPolling(t)
+	server := newTestXrayServer(t)
+	server.scanStatuses["docker-local/app/slow/manifest.json"] = []string{"IN_PROGRESS"}
+	server.scanStatuses["docker-local/app/broken/manifest.json"] = []string{"PENDING", "FAILED"}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+data "xray_artifact_summary" "test" {
+	path          = "docker-local/app/slow/manifest.json"
+	wait_for_scan = true
+	timeout       = "50ms"
+}
+`),
+				ExpectError: regexp.MustCompile(`error waiting for Xray to scan docker-local/app/slow/manifest.json: timeout while waiting`),
+			},
+			{
+				Config: server.config(`
+data "xray_artifact_summary" "test" {
+	path          = "docker-local/app/broken/manifest.json"
+	wait_for_scan = true
+}
+`),
+				ExpectError: regexp.MustCompile(`Xray failed to scan docker-local/app/broken/manifest.json`),
+			},
+			{
+				Config: server.config(`
+data "xray_artifact_summary" "test" {
+	checksum = "` + testChecksum + `"
+}
+`),
+				ExpectError: regexp.MustCompile(`Artifact doesn't exist or not indexed/cached in Xray`),
+			},
+			{
+				Config: server.config(`
+data "xray_artifact_summary" "test" {
+	wait_for_scan = true
+}
+`),
+				ExpectError: regexp.MustCompile(`one of path or checksum must be set`),
+			},
+		},
+	})
+}
@@ -0,0 +1,305 @@
+package jfrogxray
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Component IDs are prefixed with the package type, e.g. docker://org/app:1.2.3 or gav://group:artifact:version
+var validateComponentID = validation.StringMatch(
+	regexp.MustCompile(`^[a-z0-9]+://\S+$`),
+	"must be an Xray component ID such as docker://org/app:1.2.3, gav://group:artifact:version or npm://package:version",
+)
+
+// Reads the vulnerabilities, licenses and operational risk Xray knows about for a set of components
+func dataSourceXrayComponentSummary() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceXrayComponentSummaryRead,
+
+		Schema: map[string]*schema.Schema{
+			"component_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateComponentID,
+				},
+			},
+
+			"components": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"component_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"package_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issues":   summaryIssuesSchema(),
+						"licenses": summaryLicensesSchema(),
+						"operational_risk": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"risk": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"risk_reason": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"is_eol": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"eol_message": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"latest_version": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"newer_versions": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// summaryIssuesSchema and summaryLicensesSchema describe the issues and licenses of the Xray summary APIs
+func summaryIssuesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"issue_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"summary": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"issue_type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"severity": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"provider": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"created": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"cves": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"cvss_v2": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"cvss_v3": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+				"fixed_versions": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func summaryLicensesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"full_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"more_info_urls": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// Fixed versions are listed per affected component, which for a single component are all the same
+func flattenSummaryIssues(issues []xraySummaryIssue) []interface{} {
+	l := make([]interface{}, 0, len(issues))
+	for _, issue := range issues {
+		cves := make([]interface{}, 0, len(issue.CVEs))
+		for _, cve := range issue.CVEs {
+			cves = append(cves, map[string]interface{}{
+				"id":      cve.CVE,
+				"cvss_v2": cve.CVSSV2,
+				"cvss_v3": cve.CVSSV3,
+			})
+		}
+
+		fixed := []string{}
+		seen := map[string]bool{}
+		for _, c := range issue.Components {
+			for _, v := range c.FixedVersions {
+				if !seen[v] {
+					seen[v] = true
+					fixed = append(fixed, v)
+				}
+			}
+		}
+
+		l = append(l, map[string]interface{}{
+			"issue_id":       issue.IssueID,
+			"summary":        issue.Summary,
+			"description":    issue.Description,
+			"issue_type":     issue.IssueType,
+			"severity":       issue.Severity,
+			"provider":       issue.Provider,
+			"created":        issue.Created,
+			"cves":           cves,
+			"fixed_versions": fixed,
+		})
+	}
+	return l
+}
+
+func flattenSummaryLicenses(licenses []xraySummaryLicense) []interface{} {
+	l := make([]interface{}, 0, len(licenses))
+	for _, license := range licenses {
+		l = append(l, map[string]interface{}{
+			"name":           license.Name,
+			"full_name":      license.FullName,
+			"more_info_urls": license.MoreInfoURL,
+		})
+	}
+	return l
+}
+
+// summaryErrors turns the components or artifacts Xray couldn't find into an error, rather than leaving them out
+// of a gate that would then pass
+func summaryErrors(errors []xraySummaryError) error {
+	var errs *multierror.Error
+	for _, e := range errors {
+		errs = multierror.Append(errs, fmt.Errorf("%s: %s", e.Identifier, e.Error))
+	}
+	return errs.ErrorOrNil()
+}
+
+func dataSourceXrayComponentSummaryRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	ids := []string{}
+	for _, id := range d.Get("component_ids").([]interface{}) {
+		ids = append(ids, id.(string))
+	}
+
+	summary, _, err := c.getComponentSummary(context.Background(), ids)
+	if err != nil {
+		return err
+	}
+	if err := summaryErrors(summary.Errors); err != nil {
+		return err
+	}
+
+	// Xray versions before the operational risk API just don't report it
+	risks := map[string]interface{}{}
+	operationalRisk, resp, err := c.getOperationalRisk(context.Background(), ids)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		log.Printf("[WARN] Xray doesn't support the operational risk API, leaving operational_risk empty")
+	} else if err != nil {
+		return err
+	} else {
+		for _, r := range operationalRisk.Components {
+			risks[r.ComponentID] = map[string]interface{}{
+				"risk":           r.Risk,
+				"risk_reason":    r.RiskReason,
+				"is_eol":         r.IsEOL,
+				"eol_message":    r.EOLMessage,
+				"latest_version": r.LatestVersion,
+				"newer_versions": r.NewerVersions,
+			}
+		}
+	}
+
+	components := make([]interface{}, 0, len(summary.Artifacts))
+	for _, a := range summary.Artifacts {
+		risk := []interface{}{}
+		if r, ok := risks[a.General.ComponentID]; ok {
+			risk = append(risk, r)
+		}
+		components = append(components, map[string]interface{}{
+			"component_id":     a.General.ComponentID,
+			"name":             a.General.Name,
+			"package_type":     a.General.PkgType,
+			"issues":           flattenSummaryIssues(a.Issues),
+			"licenses":         flattenSummaryLicenses(a.Licenses),
+			"operational_risk": risk,
+		})
+	}
+	if err := d.Set("components", components); err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(ids, ","))))
+	return nil
+}
@@ -0,0 +1,97 @@
+package jfrogxray
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceXrayComponentSummary(t *testing.T) {
+	server := newTestXrayServer(t)
+	server.components["docker://org/app:1.2.3"] = testComponentSummary("docker://org/app:1.2.3", "app", "Critical")
+	server.components["npm://lodash:4.17.21"] = testComponentSummary("npm://lodash:4.17.21", "lodash", "")
+	server.operationalRisks["npm://lodash:4.17.21"] = map[string]interface{}{
+		"component_id":   "npm://lodash:4.17.21",
+		"risk":           "Low",
+		"is_eol":         false,
+		"latest_version": "4.17.21",
+	}
+	dataSourceName := "data.xray_component_summary.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+data "xray_component_summary" "test" {
+	component_ids = ["docker://org/app:1.2.3", "npm://lodash:4.17.21"]
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "components.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "components.0.component_id", "docker://org/app:1.2.3"),
+					resource.TestCheckResourceAttr(dataSourceName, "components.0.issues.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "components.0.issues.0.severity", "Critical"),
+					resource.TestCheckResourceAttr(dataSourceName, "components.0.issues.0.cves.0.id", "CVE-2021-44228"),
+					resource.TestCheckResourceAttr(dataSourceName, "components.0.issues.0.fixed_versions.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "components.0.licenses.0.name", "MIT"),
+					resource.TestCheckResourceAttr(dataSourceName, "components.0.operational_risk.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "components.1.issues.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "components.1.operational_risk.0.risk", "Low"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceXrayComponentSummary_notFound(t *testing.T) {
+	server := newTestXrayServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+data "xray_component_summary" "test" {
+	component_ids = ["npm://unknown:1.0.0"]
+}
+`),
+				ExpectError: regexp.MustCompile(`npm://unknown:1.0.0: Component not found`),
+			},
+			{
+				Config: server.config(`
+data "xray_component_summary" "test" {
+	component_ids = ["lodash:4.17.21"]
+}
+`),
+				ExpectError: regexp.MustCompile(`must be an Xray component ID`),
+			},
+		},
+	})
+}
+
+// testComponentSummary is the summary of a component with a log4shell issue of the given severity, if any
+func testComponentSummary(componentID, name, severity string) map[string]interface{} {
+	issues := []interface{}{}
+	if severity != "" {
+		issues = append(issues, map[string]interface{}{
+			"issue_id":   "XRAY-191834",
+			"summary":    "Log4Shell",
+			"issue_type": "security",
+			"severity":   severity,
+			"provider":   "JFrog",
+			"cves":       []interface{}{map[string]interface{}{"cve": "CVE-2021-44228", "cvss_v3": "10.0/CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}},
+			"components": []interface{}{
+				map[string]interface{}{"component_id": "gav://org.apache.logging.log4j:log4j-core:2.14.1", "fixed_versions": []string{"[2.15.0]", "[2.17.1]"}},
+				map[string]interface{}{"component_id": "gav://org.apache.logging.log4j:log4j-api:2.14.1", "fixed_versions": []string{"[2.17.1]"}},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"general":  map[string]interface{}{"component_id": componentID, "name": name, "pkg_type": "Docker"},
+		"issues":   issues,
+		"licenses": []interface{}{map[string]interface{}{"name": "MIT", "full_name": "The MIT License", "more_info_url": []string{"https://opensource.org/licenses/MIT"}}},
+	}
+}
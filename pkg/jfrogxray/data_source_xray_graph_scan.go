@@ -0,0 +1,491 @@
+package jfrogxray
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Scans a dependency graph that isn't stored in Artifactory, given as dependency blocks or as an SBOM
+func dataSourceXrayGraphScan() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceXrayGraphScanRead,
+
+		Schema: map[string]*schema.Schema{
+			"component_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"dependency": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"sbom"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"component_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateComponentID,
+						},
+						"parent": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"sbom": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"dependency"},
+				ValidateFunc:  validateExtraJSON,
+			},
+			"watches": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"project"},
+				Elem:          &schema.Schema{Type: schema.TypeString},
+			},
+			"project": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"watches"},
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "10m",
+				ValidateFunc: validateDuration,
+			},
+
+			"scan_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"skipped_components": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"violations":      graphScanIssuesSchema(),
+			"vulnerabilities": graphScanIssuesSchema(),
+			"licenses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"license_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"components": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func graphScanIssuesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"issue_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"summary": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"severity": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"watch_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"cves": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"id": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"cvss_v2_score": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"cvss_v3_score": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+				"components": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"component_id": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"fixed_versions": {
+								Type:     schema.TypeList,
+								Computed: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandDependencyGraph builds the graph from dependency blocks. Dependencies without a parent depend on the root.
+func expandDependencyGraph(root string, dependencies []interface{}) (*xrayGraphNode, error) {
+	nodes := map[string]*xrayGraphNode{root: {ComponentID: root}}
+	parents := map[string]string{}
+	order := []string{}
+	for _, raw := range dependencies {
+		dep := raw.(map[string]interface{})
+		id := dep["component_id"].(string)
+		if _, ok := nodes[id]; ok {
+			return nil, fmt.Errorf("dependency %s is declared more than once", id)
+		}
+		nodes[id] = &xrayGraphNode{ComponentID: id}
+		parents[id] = dep["parent"].(string)
+		order = append(order, id)
+	}
+
+	for _, id := range order {
+		parent := parents[id]
+		if parent == "" {
+			parent = root
+		}
+		p, ok := nodes[parent]
+		if !ok {
+			return nil, fmt.Errorf("the parent %s of dependency %s is not declared", parent, id)
+		}
+		p.Nodes = append(p.Nodes, nodes[id])
+	}
+
+	// A dependency that is its own ancestor is never reached from the root
+	reached := map[string]bool{}
+	var walk func(n *xrayGraphNode)
+	walk = func(n *xrayGraphNode) {
+		if reached[n.ComponentID] {
+			return
+		}
+		reached[n.ComponentID] = true
+		for _, c := range n.Nodes {
+			walk(c)
+		}
+	}
+	walk(nodes[root])
+	for _, id := range order {
+		if !reached[id] {
+			return nil, fmt.Errorf("dependency %s is part of a cycle", id)
+		}
+	}
+
+	return nodes[root], nil
+}
+
+// purlComponentIDPrefixes maps package URL types to the package types of Xray component IDs
+var purlComponentIDPrefixes = map[string]string{
+	"cargo":    "cargo",
+	"composer": "composer",
+	"gem":      "gem",
+	"golang":   "go",
+	"maven":    "gav",
+	"npm":      "npm",
+	"nuget":    "nuget",
+	"pypi":     "pypi",
+}
+
+// componentIDFromPURL converts a package URL, e.g. pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1, to an
+// Xray component ID, e.g. gav://org.apache.logging.log4j:log4j-core:2.14.1
+func componentIDFromPURL(purl string) (string, bool) {
+	s := strings.TrimPrefix(purl, "pkg:")
+	if s == purl {
+		return "", false
+	}
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		s = s[:i]
+	}
+	at := strings.LastIndex(s, "@")
+	if at < 0 {
+		return "", false
+	}
+	version, err := url.PathUnescape(s[at+1:])
+	if err != nil || version == "" {
+		return "", false
+	}
+	parts := strings.Split(s[:at], "/")
+	if len(parts) < 2 {
+		return "", false
+	}
+	prefix, ok := purlComponentIDPrefixes[strings.ToLower(parts[0])]
+	if !ok {
+		return "", false
+	}
+	for i := range parts {
+		if parts[i], err = url.PathUnescape(parts[i]); err != nil {
+			return "", false
+		}
+	}
+
+	name := strings.Join(parts[1:], "/")
+	if prefix == "gav" {
+		name = strings.Join(parts[1:], ":")
+	}
+	return fmt.Sprintf("%s://%s:%s", prefix, name, version), true
+}
+
+type cycloneDXComponent struct {
+	Name       string               `json:"name"`
+	PURL       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type sbomDocument struct {
+	// CycloneDX
+	BOMFormat string `json:"bomFormat"`
+	Metadata  struct {
+		Component *cycloneDXComponent `json:"component"`
+	} `json:"metadata"`
+	Components []cycloneDXComponent `json:"components"`
+
+	// SPDX
+	SPDXVersion string `json:"spdxVersion"`
+	Name        string `json:"name"`
+	Packages    []struct {
+		Name         string `json:"name"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+// expandSBOMGraph builds a graph from a CycloneDX or SPDX JSON document, with every component the SBOM lists as a
+// direct dependency of the root. Components without a package URL of a type Xray can scan are returned as skipped.
+func expandSBOMGraph(root, sbom string) (*xrayGraphNode, []string, error) {
+	var doc sbomDocument
+	if err := json.Unmarshal([]byte(sbom), &doc); err != nil {
+		return nil, nil, err
+	}
+
+	// The package URL of every component, or its name if it has none
+	purls := []string{}
+	var collect func(components []cycloneDXComponent)
+	collect = func(components []cycloneDXComponent) {
+		for _, c := range components {
+			purl := c.PURL
+			if purl == "" {
+				purl = c.Name
+			}
+			purls = append(purls, purl)
+			collect(c.Components)
+		}
+	}
+
+	switch {
+	case doc.BOMFormat == "CycloneDX":
+		collect(doc.Components)
+		if root == "" && doc.Metadata.Component != nil {
+			root = doc.Metadata.Component.Name
+			if id, ok := componentIDFromPURL(doc.Metadata.Component.PURL); ok {
+				root = id
+			}
+		}
+	case doc.SPDXVersion != "":
+		for _, p := range doc.Packages {
+			purl := p.Name
+			for _, ref := range p.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					purl = ref.ReferenceLocator
+				}
+			}
+			purls = append(purls, purl)
+		}
+		if root == "" {
+			root = doc.Name
+		}
+	default:
+		return nil, nil, fmt.Errorf("sbom must be a CycloneDX or SPDX JSON document")
+	}
+	if root == "" {
+		root = "sbom"
+	}
+
+	graph := &xrayGraphNode{ComponentID: root}
+	skipped := []string{}
+	seen := map[string]bool{}
+	for _, purl := range purls {
+		id, ok := componentIDFromPURL(purl)
+		if !ok {
+			skipped = append(skipped, purl)
+			continue
+		}
+		if id != root && !seen[id] {
+			seen[id] = true
+			graph.Nodes = append(graph.Nodes, &xrayGraphNode{ComponentID: id})
+		}
+	}
+	return graph, skipped, nil
+}
+
+func flattenGraphScanIssues(issues []xrayGraphScanIssue) []interface{} {
+	l := make([]interface{}, 0, len(issues))
+	for _, issue := range issues {
+		cves := make([]interface{}, 0, len(issue.CVEs))
+		for _, cve := range issue.CVEs {
+			cves = append(cves, map[string]interface{}{
+				"id":            cve.CVE,
+				"cvss_v2_score": cve.CVSSV2Score,
+				"cvss_v3_score": cve.CVSSV3Score,
+			})
+		}
+
+		ids := make([]string, 0, len(issue.Components))
+		for id := range issue.Components {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		components := make([]interface{}, 0, len(ids))
+		for _, id := range ids {
+			components = append(components, map[string]interface{}{
+				"component_id":   id,
+				"fixed_versions": issue.Components[id].FixedVersions,
+			})
+		}
+
+		l = append(l, map[string]interface{}{
+			"issue_id":   issue.IssueID,
+			"summary":    issue.Summary,
+			"severity":   issue.Severity,
+			"type":       issue.Type,
+			"watch_name": issue.WatchName,
+			"cves":       cves,
+			"components": components,
+		})
+	}
+	return l
+}
+
+func flattenGraphScanLicenses(licenses []xrayGraphScanLicense) []interface{} {
+	l := make([]interface{}, 0, len(licenses))
+	for _, license := range licenses {
+		ids := make([]string, 0, len(license.Components))
+		for id := range license.Components {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		l = append(l, map[string]interface{}{
+			"license_key": license.LicenseKey,
+			"components":  ids,
+		})
+	}
+	return l
+}
+
+func dataSourceXrayGraphScanRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+	ctx := context.Background()
+
+	root := d.Get("component_id").(string)
+	var graph *xrayGraphNode
+	skipped := []string{}
+	var err error
+	if sbom, ok := d.GetOk("sbom"); ok {
+		graph, skipped, err = expandSBOMGraph(root, sbom.(string))
+	} else if dependencies, ok := d.GetOk("dependency"); ok {
+		if root == "" {
+			return fmt.Errorf("component_id must be set with dependency blocks")
+		}
+		graph, err = expandDependencyGraph(root, dependencies.([]interface{}))
+	} else {
+		return fmt.Errorf("one of dependency or sbom must be set")
+	}
+	if err != nil {
+		return err
+	}
+	if len(skipped) > 0 {
+		log.Printf("[WARN] Xray can't scan %d components of the SBOM: %s", len(skipped), strings.Join(skipped, ", "))
+	}
+
+	watches := []string{}
+	for _, w := range d.Get("watches").([]interface{}) {
+		watches = append(watches, w.(string))
+	}
+	timeout, err := time.ParseDuration(d.Get("timeout").(string))
+	if err != nil {
+		return err
+	}
+
+	scan, _, err := c.scanGraph(ctx, graph, watches, d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	// Xray answers with 202 until the scan is done
+	raw, err := waitForScan(graph.ComponentID, timeout, func() (interface{}, string, error) {
+		result, resp, err := c.getGraphScanResult(ctx, scan.ScanID)
+		if err != nil {
+			return nil, "", err
+		}
+		if resp.StatusCode == http.StatusAccepted {
+			return result, "IN_PROGRESS", nil
+		}
+		return result, "DONE", nil
+	})
+	if err != nil {
+		return err
+	}
+	result := raw.(*xrayGraphScanResult)
+
+	if err := d.Set("scan_id", scan.ScanID); err != nil {
+		return err
+	}
+	if err := d.Set("skipped_components", skipped); err != nil {
+		return err
+	}
+	if err := d.Set("violations", flattenGraphScanIssues(result.Violations)); err != nil {
+		return err
+	}
+	if err := d.Set("vulnerabilities", flattenGraphScanIssues(result.Vulnerabilities)); err != nil {
+		return err
+	}
+	if err := d.Set("licenses", flattenGraphScanLicenses(result.Licenses)); err != nil {
+		return err
+	}
+
+	d.SetId(scan.ScanID)
+	return nil
+}
@@ -0,0 +1,196 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestDataSourceXrayGraphScan_dependencies(t *testing.T) {
+	fastScanPolling(t)
+	server := newTestXrayServer(t)
+	server.graphPolls = 2
+	server.graphIssues["npm://minimist:1.2.5"] = testGraphIssue("npm://minimist:1.2.5", "1.2.6")
+	dataSourceName := "data.xray_graph_scan.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+data "xray_graph_scan" "test" {
+	component_id = "lambda-layer:1.0"
+	watches      = ["prod"]
+
+	dependency {
+		component_id = "npm://mkdirp:0.5.5"
+	}
+	dependency {
+		component_id = "npm://minimist:1.2.5"
+		parent       = "npm://mkdirp:0.5.5"
+	}
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "scan_id", "scan-1"),
+					resource.TestCheckResourceAttr(dataSourceName, "violations.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "violations.0.watch_name", "prod"),
+					resource.TestCheckResourceAttr(dataSourceName, "violations.0.severity", "Critical"),
+					resource.TestCheckResourceAttr(dataSourceName, "violations.0.cves.0.id", "CVE-2021-44906"),
+					resource.TestCheckResourceAttr(dataSourceName, "violations.0.components.0.component_id", "npm://minimist:1.2.5"),
+					resource.TestCheckResourceAttr(dataSourceName, "violations.0.components.0.fixed_versions.0", "1.2.6"),
+					resource.TestCheckResourceAttr(dataSourceName, "vulnerabilities.#", "0"),
+					func(*terraform.State) error {
+						graph := server.graphScans[0].graph
+						if graph.ComponentID != "lambda-layer:1.0" || len(graph.Nodes) != 1 || len(graph.Nodes[0].Nodes) != 1 ||
+							graph.Nodes[0].Nodes[0].ComponentID != "npm://minimist:1.2.5" {
+							return fmt.Errorf("unexpected graph %+v", graph)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceXrayGraphScan_sbom(t *testing.T) {
+	fastScanPolling(t)
+	server := newTestXrayServer(t)
+	server.graphIssues["gav://org.apache.logging.log4j:log4j-core:2.14.1"] = testGraphIssue("gav://org.apache.logging.log4j:log4j-core:2.14.1", "2.17.1")
+	dataSourceName := "data.xray_graph_scan.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+data "xray_graph_scan" "test" {
+	sbom = jsonencode({
+		bomFormat   = "CycloneDX"
+		specVersion = "1.4"
+		metadata = {
+			component = { name = "vendor-bundle", purl = "pkg:npm/vendor-bundle@3.0.0" }
+		}
+		components = [
+			{
+				name       = "log4j-core"
+				purl       = "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"
+				components = [{ name = "lodash", purl = "pkg:npm/lodash@4.17.21" }]
+			},
+			{ name = "zlib", purl = "pkg:deb/debian/zlib1g@1.2.11" },
+			{ name = "vendored" },
+		]
+	})
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "vulnerabilities.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "vulnerabilities.0.issue_id", "XRAY-1"),
+					resource.TestCheckResourceAttr(dataSourceName, "violations.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "skipped_components.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "skipped_components.0", "pkg:deb/debian/zlib1g@1.2.11"),
+					resource.TestCheckResourceAttr(dataSourceName, "skipped_components.1", "vendored"),
+					func(*terraform.State) error {
+						graph := server.graphScans[0].graph
+						ids := []string{}
+						for _, n := range graph.Nodes {
+							ids = append(ids, n.ComponentID)
+						}
+						if graph.ComponentID != "npm://vendor-bundle:3.0.0" || strings.Join(ids, ",") != "gav://org.apache.logging.log4j:log4j-core:2.14.1,npm://lodash:4.17.21" {
+							return fmt.Errorf("unexpected graph %s with %v", graph.ComponentID, ids)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: server.config(`
+data "xray_graph_scan" "test" {
+	sbom = jsonencode({ packages = [] })
+}
+`),
+				ExpectError: regexp.MustCompile(`sbom must be a CycloneDX or SPDX JSON document`),
+			},
+		},
+	})
+}
+
+func TestComponentIDFromPURL(t *testing.T) {
+	cases := map[string]string{
+		"pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1?type=jar": "gav://org.apache.logging.log4j:log4j-core:2.14.1",
+		"pkg:npm/%40angular/core@12.0.0":                                "npm://@angular/core:12.0.0",
+		"pkg:pypi/requests@2.25.1":                                      "pypi://requests:2.25.1",
+		"pkg:golang/github.com/gin-gonic/gin@v1.7.0#subpath":            "go://github.com/gin-gonic/gin:v1.7.0",
+	}
+	for purl, expected := range cases {
+		if id, ok := componentIDFromPURL(purl); !ok || id != expected {
+			t.Errorf("expected %s for %s, got %q", expected, purl, id)
+		}
+	}
+
+	for _, purl := range []string{"npm/lodash@4.17.21", "pkg:npm/lodash", "pkg:deb/debian/zlib1g@1.2.11", "pkg:npm@1.0"} {
+		if id, ok := componentIDFromPURL(purl); ok {
+			t.Errorf("expected %s to be skipped, got %s", purl, id)
+		}
+	}
+}
+
+func TestExpandSBOMGraph_spdx(t *testing.T) {
+	sbom := `{
+		"spdxVersion": "SPDX-2.3",
+		"name": "vendor-bundle",
+		"packages": [
+			{"name": "requests", "externalRefs": [{"referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:python:requests"},
+				{"referenceType": "purl", "referenceLocator": "pkg:pypi/requests@2.25.1"}]},
+			{"name": "requests-again", "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:pypi/requests@2.25.1"}]},
+			{"name": "internal-tool"}
+		]
+	}`
+
+	graph, skipped, err := expandSBOMGraph("", sbom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if graph.ComponentID != "vendor-bundle" || len(graph.Nodes) != 1 || graph.Nodes[0].ComponentID != "pypi://requests:2.25.1" {
+		t.Errorf("unexpected graph %+v", graph)
+	}
+	if len(skipped) != 1 || skipped[0] != "internal-tool" {
+		t.Errorf("expected internal-tool to be skipped, got %v", skipped)
+	}
+}
+
+func TestExpandDependencyGraph_invalid(t *testing.T) {
+	dep := func(id, parent string) interface{} {
+		return map[string]interface{}{"component_id": id, "parent": parent}
+	}
+	cases := map[string][]interface{}{
+		"is declared more than once": {dep("npm://a:1", ""), dep("npm://a:1", "")},
+		"is not declared":            {dep("npm://a:1", "npm://b:1")},
+		"is part of a cycle":         {dep("npm://a:1", "npm://b:1"), dep("npm://b:1", "npm://a:1")},
+	}
+	for expected, dependencies := range cases {
+		if _, err := expandDependencyGraph("root", dependencies); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected an error saying a dependency %s, got %v", expected, err)
+		}
+	}
+}
+
+func testGraphIssue(componentID, fixedVersion string) map[string]interface{} {
+	cve := "CVE-2021-44906"
+	if strings.HasPrefix(componentID, "gav://") {
+		cve = "CVE-2021-44228"
+	}
+	return map[string]interface{}{
+		"issue_id":   "XRAY-1",
+		"summary":    "Test issue",
+		"severity":   "Critical",
+		"type":       "security",
+		"cves":       []interface{}{map[string]interface{}{"cve": cve, "cvss_v3_score": "9.8"}},
+		"components": map[string]interface{}{componentID: map[string]interface{}{"fixed_versions": []string{fixedVersion}}},
+	}
+}
@@ -0,0 +1,114 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// The license catalogue is embedded in the provider, so this data source never talks to Xray
+func dataSourceXrayLicenses() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceXrayLicensesRead,
+
+		Schema: map[string]*schema.Schema{
+			"category": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(validLicenseCategories, false),
+			},
+			"include_deprecated": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"licenses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deprecated": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenLicenses(licenses []licenseInfo, source, category string, includeDeprecated bool) []interface{} {
+	l := []interface{}{}
+	for _, license := range licenses {
+		if category != "" && license.Category != category {
+			continue
+		}
+		if license.Deprecated && !includeDeprecated {
+			continue
+		}
+		l = append(l, map[string]interface{}{
+			"id":         license.ID,
+			"category":   license.Category,
+			"deprecated": license.Deprecated,
+			"source":     source,
+		})
+	}
+	return l
+}
+
+func dataSourceXrayLicensesRead(d *schema.ResourceData, meta interface{}) error {
+	category := d.Get("category").(string)
+	includeDeprecated := d.Get("include_deprecated").(bool)
+
+	licenses := append(
+		flattenLicenses(spdxLicenses, licenseSourceSPDX, category, includeDeprecated),
+		flattenLicenses(xrayLicenses, licenseSourceXray, category, includeDeprecated)...,
+	)
+	sort.SliceStable(licenses, func(i, j int) bool {
+		return licenses[i].(map[string]interface{})["id"].(string) < licenses[j].(map[string]interface{})["id"].(string)
+	})
+
+	ids := make([]string, 0, len(licenses))
+	for _, l := range licenses {
+		ids = append(ids, l.(map[string]interface{})["id"].(string))
+	}
+
+	if err := d.Set("version", spdxLicenseListVersion); err != nil {
+		return err
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return err
+	}
+	if err := d.Set("licenses", licenses); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s-%s-%t", spdxLicenseListVersion, category, includeDeprecated))
+	return nil
+}
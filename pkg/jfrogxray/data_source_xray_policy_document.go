@@ -0,0 +1,64 @@
+package jfrogxray
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
+)
+
+// Builds the JSON for a policy from the same rule blocks as xray_policy, without talking to Xray. The document
+// can be used with the Xray API directly, e.g. to import the policy with the JFrog CLI or in the Xray UI.
+func dataSourceXrayPolicyDocument() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceXrayPolicyDocumentRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validatePolicyType,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"extra_json": extraJSONSchema(),
+			"rules":      policyRulesSchema(),
+
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceXrayPolicyDocumentRead(d *schema.ResourceData, meta interface{}) error {
+	// There is no plan for a data source to check, so the rules are validated here instead
+	if err := validatePolicyRules(d.Get("type").(string), d.Get("rules").([]interface{})); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(expandPolicy(d))
+	if err != nil {
+		return err
+	}
+	// Normalizing sorts the keys, so the same policy always produces the same document
+	doc, err := structure.NormalizeJsonString(string(body))
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("json", doc); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(hashcode.String(doc)))
+	return nil
+}
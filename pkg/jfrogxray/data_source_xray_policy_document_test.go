@@ -0,0 +1,103 @@
+package jfrogxray
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDataSourceXrayPolicyDocument_read(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceXrayPolicyDocument().Schema, map[string]interface{}{
+		"name": "test-policy",
+		"type": "security",
+		"rules": []interface{}{map[string]interface{}{
+			"name":     "log4j",
+			"priority": 1,
+			"criteria": []interface{}{map[string]interface{}{
+				"package_type": "maven",
+				"package_name": "org.apache.logging.log4j:log4j-core",
+			}},
+			"actions": []interface{}{map[string]interface{}{
+				"block_download": []interface{}{map[string]interface{}{"unscanned": false, "active": true}},
+				"fail_build":     false,
+			}},
+		}},
+	})
+	if err := dataSourceXrayPolicyDocumentRead(d, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"name":"test-policy","rules":[{"actions":{"block_download":{"active":true,"unscanned":false},"fail_build":false},` +
+		`"criteria":{"package_name":"org.apache.logging.log4j:log4j-core","package_type":"maven"},"name":"log4j","priority":1}],"type":"security"}`
+	if doc := d.Get("json").(string); doc != expected {
+		t.Errorf("expected %s, got %s", expected, doc)
+	}
+
+	// The document has to be usable as a policy
+	var policy xrayPolicy
+	if err := json.Unmarshal([]byte(d.Get("json").(string)), &policy); err != nil {
+		t.Fatal(err)
+	}
+	if *(*policy.Rules)[0].Criteria.PackageType != "maven" {
+		t.Errorf("expected the document to decode as a policy, got %+v", policy)
+	}
+}
+
+func TestDataSourceXrayPolicyDocument_invalid(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceXrayPolicyDocument().Schema, map[string]interface{}{
+		"name": "test-policy",
+		"type": "license",
+		"rules": []interface{}{map[string]interface{}{
+			"name":     "severity",
+			"priority": 1,
+			"criteria": []interface{}{map[string]interface{}{"min_severity": "High"}},
+		}},
+	})
+	err := dataSourceXrayPolicyDocumentRead(d, nil)
+	if err == nil || !strings.Contains(err.Error(), "criteria.min_severity cannot be used in a license policy") {
+		t.Errorf("expected the rules to be validated, got %v", err)
+	}
+}
+
+func TestAccDataSourceXrayPolicyDocument(t *testing.T) {
+	server := newTestXrayServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayPolicyDocument),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.xray_policy_document.test", "json", regexp.MustCompile(`"banned_licenses":\["MIT","AGPL-1.0-only",`)),
+					resource.TestMatchResourceAttr("data.xray_policy_document.test", "json", regexp.MustCompile(`"allow_unknown":false`)),
+				),
+			},
+		},
+	})
+}
+
+const testAccXrayPolicyDocument = `
+data "xray_policy_document" "test" {
+	name = "test-policy"
+	type = "license"
+
+	rules {
+		name = "no-copyleft"
+		priority = 1
+		criteria {
+			banned_licenses = ["MIT"]
+			banned_license_categories = ["copyleft"]
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = true
+			}
+		}
+	}
+}
+`
@@ -0,0 +1,142 @@
+package jfrogxray
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+var validSBOMFormats = []string{"cyclonedx-json", "cyclonedx-xml", "spdx-json", "spdx-tag-value"}
+
+// Builds and release bundles are exported with their own package types
+var validSBOMPackageTypes = append([]string{"build", "releaseBundle"}, validPackageTypes...)
+
+// Exports the SBOM of an artifact, build or release bundle. The document can be kept in the state, or written to a
+// local file for archiving, in which case only its checksum is kept.
+func dataSourceXraySBOM() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceXraySBOMRead,
+
+		Schema: map[string]*schema.Schema{
+			"package_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(validSBOMPackageTypes, false),
+			},
+			"component_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(validSBOMFormats, false),
+			},
+			"include_vulnerabilities": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"include_licenses": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"include_vex": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"output_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"document": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandSBOMExport(d *schema.ResourceData) (xrayExportRequest, error) {
+	req := xrayExportRequest{
+		PackageType:   d.Get("package_type").(string),
+		ComponentName: d.Get("component_name").(string),
+		Path:          "default/" + strings.TrimPrefix(d.Get("path").(string), "/"),
+		Security:      d.Get("include_vulnerabilities").(bool),
+		License:       d.Get("include_licenses").(bool),
+		VEX:           d.Get("include_vex").(bool),
+	}
+
+	switch d.Get("format").(string) {
+	case "cyclonedx-json":
+		req.CycloneDX, req.CycloneDXFormat = true, "json"
+	case "cyclonedx-xml":
+		req.CycloneDX, req.CycloneDXFormat = true, "xml"
+	case "spdx-json":
+		req.SPDX, req.SPDXFormat = true, "json"
+	case "spdx-tag-value":
+		req.SPDX, req.SPDXFormat = true, "tag:value"
+	}
+
+	// VEX is part of the CycloneDX format only
+	if req.VEX && !req.CycloneDX {
+		return req, fmt.Errorf("include_vex can only be used with a CycloneDX format")
+	}
+	return req, nil
+}
+
+func dataSourceXraySBOMRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	req, err := expandSBOMExport(d)
+	if err != nil {
+		return err
+	}
+	doc, _, err := c.exportSBOM(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(doc)
+	checksum := hex.EncodeToString(sum[:])
+
+	document := string(doc)
+	if path := d.Get("output_path").(string); path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, doc, 0644); err != nil {
+			return err
+		}
+		document = ""
+	}
+
+	if err := d.Set("document", document); err != nil {
+		return err
+	}
+	if err := d.Set("sha256", checksum); err != nil {
+		return err
+	}
+
+	d.SetId(checksum)
+	return nil
+}
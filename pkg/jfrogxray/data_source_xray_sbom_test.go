@@ -0,0 +1,101 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestDataSourceXraySBOM(t *testing.T) {
+	server := newTestXrayServer(t)
+	server.artifacts["default/docker-local/app/1.2.3/manifest.json"] = testComponentSummary("docker://app:1.2.3", "app", "")
+	outputPath := filepath.Join(t.TempDir(), "sboms", "app.spdx")
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(fmt.Sprintf(`
+data "xray_sbom" "cyclonedx" {
+	package_type            = "docker"
+	component_name          = "app:1.2.3"
+	path                    = "docker-local/app/1.2.3/manifest.json"
+	format                  = "cyclonedx-json"
+	include_vulnerabilities = true
+	include_vex             = true
+}
+
+data "xray_sbom" "spdx" {
+	package_type   = "docker"
+	component_name = "app:1.2.3"
+	path           = "docker-local/app/1.2.3/manifest.json"
+	format         = "spdx-tag-value"
+	output_path    = %q
+}
+`, outputPath)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.xray_sbom.cyclonedx", "document", `{"bomFormat":"CycloneDX","metadata":{"component":{"name":"app:1.2.3"}}}`),
+					resource.TestCheckResourceAttrSet("data.xray_sbom.cyclonedx", "sha256"),
+					resource.TestCheckResourceAttr("data.xray_sbom.spdx", "document", ""),
+					resource.TestCheckResourceAttr("data.xray_sbom.spdx", "sha256", "3ae1fa0738d2617fd1524ebdddfad7a50a3b6d5fd8de850446bc0f04d21488c5"),
+					func(*terraform.State) error {
+						doc, err := ioutil.ReadFile(outputPath)
+						if err != nil {
+							return err
+						}
+						if string(doc) != "SPDXVersion: SPDX-2.3\nDocumentName: app:1.2.3\n" {
+							return fmt.Errorf("unexpected SBOM %q", doc)
+						}
+						for _, req := range server.exports {
+							if req.CycloneDX && (!req.VEX || !req.Security || !req.License || req.SPDX) {
+								return fmt.Errorf("unexpected CycloneDX export request %+v", req)
+							}
+							if req.SPDX && (req.SPDXFormat != "tag:value" || req.VEX || req.Security) {
+								return fmt.Errorf("unexpected SPDX export request %+v", req)
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceXraySBOM_invalid(t *testing.T) {
+	server := newTestXrayServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+data "xray_sbom" "test" {
+	package_type   = "build"
+	component_name = "app:42"
+	path           = "artifactory-build-info/app/42.json"
+	format         = "spdx-json"
+	include_vex    = true
+}
+`),
+				ExpectError: regexp.MustCompile(`include_vex can only be used with a CycloneDX format`),
+			},
+			{
+				Config: server.config(`
+data "xray_sbom" "test" {
+	package_type   = "build"
+	component_name = "app:42"
+	path           = "artifactory-build-info/app/42.json"
+	format         = "spdx-json"
+}
+`),
+				ExpectError: regexp.MustCompile(`Component default/artifactory-build-info/app/42.json not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,267 @@
+package jfrogxray
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// The violations API spells violation types differently from policy types
+var violationTypes = map[string]string{
+	"security":         "Security",
+	"license":          "License",
+	"operational_risk": "Operational_Risk",
+}
+
+// Lists the violations Xray found, e.g. to check that a watch has no open critical violations before deploying
+func dataSourceXrayViolations() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceXrayViolationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"watch_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"violation_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePolicyType,
+			},
+			"min_severity": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSeverity,
+			},
+			"created_from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"created_until": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"resources": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"artifacts": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"repo": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"path": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+						"builds": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"number": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+						"release_bundles": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"version": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"total": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"violations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issue_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"watch_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"details_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"infected_components": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"impacted_artifacts": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"matched_policies": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"policy": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"rule": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"is_blocking": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandViolationsFilters(d *schema.ResourceData) xrayViolationsFilters {
+	filters := xrayViolationsFilters{
+		WatchName:     d.Get("watch_name").(string),
+		ViolationType: violationTypes[d.Get("violation_type").(string)],
+		MinSeverity:   d.Get("min_severity").(string),
+		CreatedFrom:   d.Get("created_from").(string),
+		CreatedUntil:  d.Get("created_until").(string),
+	}
+
+	if v, ok := d.GetOk("resources"); ok && v.([]interface{})[0] != nil {
+		m := v.([]interface{})[0].(map[string]interface{})
+		resources := new(xrayViolationResources)
+		for _, a := range m["artifacts"].([]interface{}) {
+			a := a.(map[string]interface{})
+			resources.Artifacts = append(resources.Artifacts, xrayViolationArtifact{Repo: a["repo"].(string), Path: a["path"].(string)})
+		}
+		for _, b := range m["builds"].([]interface{}) {
+			b := b.(map[string]interface{})
+			resources.Builds = append(resources.Builds, xrayViolationBuild{Name: b["name"].(string), Number: b["number"].(string)})
+		}
+		for _, rb := range m["release_bundles"].([]interface{}) {
+			rb := rb.(map[string]interface{})
+			resources.ReleaseBundles = append(resources.ReleaseBundles, xrayViolationReleaseBundle{Name: rb["name"].(string), Version: rb["version"].(string)})
+		}
+		filters.Resources = resources
+	}
+
+	return filters
+}
+
+func flattenViolations(violations []xrayViolation) []interface{} {
+	l := make([]interface{}, 0, len(violations))
+	for _, v := range violations {
+		policies := make([]interface{}, 0, len(v.MatchedPolicies))
+		for _, p := range v.MatchedPolicies {
+			policies = append(policies, map[string]interface{}{
+				"policy":      p.Policy,
+				"rule":        p.Rule,
+				"is_blocking": p.IsBlocking,
+			})
+		}
+
+		l = append(l, map[string]interface{}{
+			"id":                  v.ID,
+			"type":                v.Type,
+			"severity":            v.Severity,
+			"description":         v.Description,
+			"issue_id":            v.IssueID,
+			"watch_name":          v.WatchName,
+			"created":             v.Created,
+			"details_url":         v.ViolationDetailsURL,
+			"infected_components": v.InfectedComponents,
+			"impacted_artifacts":  v.ImpactedArtifacts,
+			"matched_policies":    policies,
+		})
+	}
+	return l
+}
+
+func dataSourceXrayViolationsRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	filters := expandViolationsFilters(d)
+	violations, _, err := c.searchViolations(context.Background(), filters)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("total", len(violations)); err != nil {
+		return err
+	}
+	if err := d.Set("violations", flattenViolations(violations)); err != nil {
+		return err
+	}
+
+	id, err := json.Marshal(filters)
+	if err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(hashcode.String(string(id))))
+	return nil
+}
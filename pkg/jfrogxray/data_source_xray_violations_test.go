@@ -0,0 +1,87 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDataSourceXrayViolations(t *testing.T) {
+	server := newTestXrayServer(t)
+	// More than one page for the prod watch
+	for i := 0; i < violationsPageSize+20; i++ {
+		severity := "Low"
+		if i%3 == 0 {
+			severity = "Critical"
+		}
+		server.violations = append(server.violations, testViolation(i, "prod", severity))
+	}
+	server.violations = append(server.violations, testViolation(1000, "dev", "Critical"))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+data "xray_violations" "all" {
+	watch_name = "prod"
+}
+
+data "xray_violations" "critical" {
+	watch_name     = "prod"
+	violation_type = "security"
+	min_severity   = "Critical"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.xray_violations.all", "total", "120"),
+					resource.TestCheckResourceAttr("data.xray_violations.all", "violations.#", "120"),
+					resource.TestCheckResourceAttr("data.xray_violations.all", "violations.119.id", "violation-119"),
+					resource.TestCheckResourceAttr("data.xray_violations.critical", "total", "40"),
+					resource.TestCheckResourceAttr("data.xray_violations.critical", "violations.0.severity", "Critical"),
+					resource.TestCheckResourceAttr("data.xray_violations.critical", "violations.0.issue_id", "XRAY-0"),
+					resource.TestCheckResourceAttr("data.xray_violations.critical", "violations.0.infected_components.0", "npm://lodash:4.17.15"),
+					resource.TestCheckResourceAttr("data.xray_violations.critical", "violations.0.matched_policies.0.policy", "block-critical"),
+					resource.TestCheckResourceAttr("data.xray_violations.critical", "violations.0.matched_policies.0.is_blocking", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandViolationsFilters(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceXrayViolations().Schema, map[string]interface{}{
+		"violation_type": "operational_risk",
+		"created_from":   "2021-12-10T00:00:00Z",
+		"resources": []interface{}{map[string]interface{}{
+			"artifacts": []interface{}{map[string]interface{}{"repo": "docker-local", "path": "app/1.2.3/manifest.json"}},
+			"builds":    []interface{}{map[string]interface{}{"name": "app"}},
+		}},
+	})
+
+	filters := expandViolationsFilters(d)
+	if filters.ViolationType != "Operational_Risk" || filters.CreatedFrom != "2021-12-10T00:00:00Z" {
+		t.Errorf("unexpected filters %+v", filters)
+	}
+	if filters.Resources == nil || len(filters.Resources.Artifacts) != 1 || filters.Resources.Builds[0].Name != "app" || filters.Resources.ReleaseBundles != nil {
+		t.Errorf("unexpected resources %+v", filters.Resources)
+	}
+}
+
+func testViolation(i int, watch, severity string) map[string]interface{} {
+	return map[string]interface{}{
+		"violation_id":          fmt.Sprintf("violation-%d", i),
+		"type":                  "Security",
+		"severity":              severity,
+		"description":           "Prototype pollution",
+		"issue_id":              fmt.Sprintf("XRAY-%d", i),
+		"watch_name":            watch,
+		"created":               "2021-12-10T12:00:00Z",
+		"violation_details_url": fmt.Sprintf("https://xray.example.com/ui/violations/%d", i),
+		"infected_components":   []string{"npm://lodash:4.17.15"},
+		"impacted_artifacts":    []string{"default/npm-local/app/-/app-1.0.0.tgz"},
+		"matched_policies":      []map[string]interface{}{{"policy": "block-critical", "rule": "critical", "is_blocking": severity == "Critical"}},
+	}
+}
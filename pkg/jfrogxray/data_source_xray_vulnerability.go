@@ -0,0 +1,229 @@
+package jfrogxray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Looks up an issue in the Xray database, so that ignore rules and vulnerability specific policies can refer to
+// what Xray actually knows about it
+func dataSourceXrayVulnerability() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceXrayVulnerabilityRead,
+
+		Schema: map[string]*schema.Schema{
+			"issue_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateVulnerabilityID,
+			},
+
+			"xray_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"provider_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"package_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"severity": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"summary": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cvss_v2_score": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cvss_v3_score": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cves": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cvss_v2_score": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cvss_v2_vector": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cvss_v3_score": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cvss_v3_vector": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"components": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vulnerable_versions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"fixed_versions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"sources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenIssueEventCVEs(cves []xrayIssueEventCVE) []interface{} {
+	l := make([]interface{}, 0, len(cves))
+	for _, cve := range cves {
+		v2Score, v2Vector := splitCVSS(cve.CVSSV2)
+		v3Score, v3Vector := splitCVSS(cve.CVSSV3)
+		l = append(l, map[string]interface{}{
+			"id":             cve.CVE,
+			"cvss_v2_score":  v2Score,
+			"cvss_v2_vector": v2Vector,
+			"cvss_v3_score":  v3Score,
+			"cvss_v3_vector": v3Vector,
+		})
+	}
+	return l
+}
+
+func flattenIssueEventComponents(components []xrayIssueEventComponent) []interface{} {
+	l := make([]interface{}, 0, len(components))
+	for _, c := range components {
+		l = append(l, map[string]interface{}{
+			"id":                  c.ID,
+			"vulnerable_versions": c.VulnerableVersions,
+			"fixed_versions":      c.FixedVersions,
+		})
+	}
+	return l
+}
+
+func flattenIssueEventSources(sources []xrayIssueEventSource) []interface{} {
+	l := make([]interface{}, 0, len(sources))
+	for _, s := range sources {
+		l = append(l, map[string]interface{}{
+			"source_id": s.SourceID,
+			"name":      s.Name,
+			"url":       s.URL,
+		})
+	}
+	return l
+}
+
+func dataSourceXrayVulnerabilityRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	ctx := context.Background()
+	id := d.Get("issue_id").(string)
+	issueID := id
+	if strings.HasPrefix(id, "CVE-") {
+		resolved, err := c.resolveCVE(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error looking up the Xray issue of %s: %s", id, err)
+		} else if resolved == "" {
+			return fmt.Errorf("Xray doesn't know issue %s", id)
+		}
+		issueID = resolved
+	}
+
+	event, resp, err := c.getIssueEvent(ctx, issueID)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("Xray doesn't know issue %s", id)
+	} else if err != nil {
+		return err
+	}
+
+	// The top level scores are those of the CVE that was looked up, or of the first CVE of the issue
+	var v2Score, v3Score string
+	for i, cve := range event.CVEs {
+		if i == 0 || cve.CVE == id {
+			v2Score, _ = splitCVSS(cve.CVSSV2)
+			v3Score, _ = splitCVSS(cve.CVSSV3)
+		}
+	}
+
+	fields := map[string]interface{}{
+		"xray_id":       issueID,
+		"type":          event.Type,
+		"provider_name": event.Provider,
+		"package_type":  event.PackageType,
+		"severity":      event.Severity,
+		"summary":       event.Summary,
+		"description":   event.Description,
+		"cvss_v2_score": v2Score,
+		"cvss_v3_score": v3Score,
+		"cves":          flattenIssueEventCVEs(event.CVEs),
+		"components":    flattenIssueEventComponents(event.Components),
+		"sources":       flattenIssueEventSources(event.Sources),
+	}
+	for k, v := range fields {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(id)
+	return nil
+}
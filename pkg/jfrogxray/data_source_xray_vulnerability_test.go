@@ -0,0 +1,98 @@
+package jfrogxray
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestDataSourceXrayVulnerability(t *testing.T) {
+	server := newTestXrayServer(t)
+	server.events["XRAY-191834"] = map[string]interface{}{
+		"id":           "XRAY-191834",
+		"type":         "Security",
+		"provider":     "JFrog",
+		"package_type": "maven",
+		"severity":     "Critical",
+		"summary":      "Log4Shell",
+		"description":  "Apache Log4j2 JNDI features do not protect against attacker controlled LDAP endpoints.",
+		"components": []interface{}{map[string]interface{}{
+			"id":                  "org.apache.logging.log4j:log4j-core",
+			"vulnerable_versions": []string{"[2.0-beta9,2.15.0)"},
+			"fixed_versions":      []string{"2.15.0"},
+		}},
+		"cves": []interface{}{
+			map[string]interface{}{"cve": "CVE-2021-44228", "cvss_v2": "9.3/AV:N/AC:M/Au:N/C:C/I:C/A:C", "cvss_v3": "10.0/CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"},
+			map[string]interface{}{"cve": "CVE-2021-45046", "cvss_v3": "9.0/CVSS:3.1/AV:N/AC:H/PR:N/UI:N/S:C/C:H/I:H/A:H"},
+		},
+		"sources": []interface{}{map[string]interface{}{"name": "NVD", "url": "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"}},
+	}
+	// CVEs are resolved to the Xray issue through the components they affect
+	server.components["gav://org.apache.logging.log4j:log4j-core:2.14.1"] = map[string]interface{}{
+		"general": map[string]interface{}{"component_id": "gav://org.apache.logging.log4j:log4j-core:2.14.1", "name": "log4j-core", "pkg_type": "Maven"},
+		"issues": []interface{}{map[string]interface{}{
+			"issue_id": "XRAY-191834",
+			"cves": []interface{}{
+				map[string]interface{}{"cve": "CVE-2021-44228"},
+				map[string]interface{}{"cve": "CVE-2021-45046"},
+			},
+		}},
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+data "xray_vulnerability" "by_id" {
+	issue_id = "XRAY-191834"
+}
+
+data "xray_vulnerability" "by_cve" {
+	issue_id = "CVE-2021-45046"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "severity", "Critical"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "provider_name", "JFrog"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "cvss_v2_score", "9.3"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "cvss_v3_score", "10.0"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "cves.#", "2"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "cves.0.cvss_v3_vector", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "components.0.id", "org.apache.logging.log4j:log4j-core"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "components.0.vulnerable_versions.0", "[2.0-beta9,2.15.0)"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "components.0.fixed_versions.0", "2.15.0"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "sources.0.name", "NVD"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_id", "xray_id", "XRAY-191834"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_cve", "xray_id", "XRAY-191834"),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_cve", "summary", "Log4Shell"),
+					// The scores of the CVE that was looked up
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_cve", "cvss_v2_score", ""),
+					resource.TestCheckResourceAttr("data.xray_vulnerability.by_cve", "cvss_v3_score", "9.0"),
+				),
+			},
+			{
+				Config: server.config(`
+data "xray_vulnerability" "test" {
+	issue_id = "CVE-2099-0001"
+}
+`),
+				ExpectError: regexp.MustCompile(`Xray doesn't know issue CVE-2099-0001`),
+			},
+		},
+	})
+}
+
+func TestSplitCVSS(t *testing.T) {
+	cases := map[string][2]string{
+		"10.0/CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H": {"10.0", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"},
+		"5.0": {"5.0", ""},
+		"":    {"", ""},
+	}
+	for cvss, expected := range cases {
+		if score, vector := splitCVSS(cvss); score != expected[0] || vector != expected[1] {
+			t.Errorf("expected %v for %q, got %q and %q", expected, cvss, score, vector)
+		}
+	}
+}
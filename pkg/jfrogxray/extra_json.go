@@ -0,0 +1,153 @@
+package jfrogxray
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
+)
+
+// extra_json lets a configuration send fields that go-xray and this provider don't know about yet. The JSON object
+// is merged into the request body, and only the keys it sets are compared with what Xray returns.
+func extraJSONSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateFunc:     validateExtraJSON,
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+		StateFunc: func(v interface{}) string {
+			s, _ := structure.NormalizeJsonString(v)
+			return s
+		},
+	}
+}
+
+func validateExtraJSON(v interface{}, k string) (ws []string, es []error) {
+	if _, err := structure.ExpandJsonFromString(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s must be a JSON object: %s", k, err))
+	}
+	return
+}
+
+// marshalWithExtraJSON encodes v for a request. If base is given, v is laid over it first, so that fields of an
+// object read from Xray that v can't represent are sent back unchanged. Arrays in v replace those in base.
+// The extra JSON is then merged in: objects are merged recursively and arrays element by element, so extra
+// fields can be added to e.g. individual policy rules.
+func marshalWithExtraJSON(v interface{}, base json.RawMessage, extra string) ([]byte, error) {
+	body, err := json.Marshal(v)
+	if err != nil || (len(base) == 0 && extra == "") {
+		return body, err
+	}
+
+	var merged interface{}
+	if err := json.Unmarshal(body, &merged); err != nil {
+		return nil, err
+	}
+	if len(base) > 0 {
+		var b interface{}
+		if err := json.Unmarshal(base, &b); err != nil {
+			return nil, err
+		}
+		merged = mergeJSON(b, merged, false)
+	}
+	if extra != "" {
+		e, err := structure.ExpandJsonFromString(extra)
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeJSON(merged, e, true)
+	}
+
+	return json.Marshal(merged)
+}
+
+// mergeJSON merges src into dst, preferring src. Arrays are merged element by element if mergeArrays is set, and
+// replaced otherwise.
+func mergeJSON(dst, src interface{}, mergeArrays bool) interface{} {
+	switch s := src.(type) {
+	case map[string]interface{}:
+		d, ok := dst.(map[string]interface{})
+		if !ok {
+			return s
+		}
+		for k, v := range s {
+			d[k] = mergeJSON(d[k], v, mergeArrays)
+		}
+		return d
+	case []interface{}:
+		d, ok := dst.([]interface{})
+		if !ok || !mergeArrays {
+			return s
+		}
+		for i, v := range s {
+			if i < len(d) {
+				d[i] = mergeJSON(d[i], v, mergeArrays)
+			} else {
+				d = append(d, v)
+			}
+		}
+		return d
+	default:
+		return s
+	}
+}
+
+// flattenExtraJSON picks the keys set in the configured extra JSON out of a response body, so that drift in those
+// keys shows up as a diff. Keys missing from the response are left out, unless keepMissing is set. The extra_json
+// of watches and policies sets it, because Xray accepts some of their keys without ever returning them.
+func flattenExtraJSON(response json.RawMessage, extra string, keepMissing bool) (string, error) {
+	if extra == "" {
+		return "", nil
+	}
+
+	e, err := structure.ExpandJsonFromString(extra)
+	if err != nil {
+		return "", err
+	}
+	var r interface{}
+	if err := json.Unmarshal(response, &r); err != nil {
+		return "", err
+	}
+
+	picked, _ := pickJSON(r, e, keepMissing).(map[string]interface{})
+	if picked == nil {
+		picked = map[string]interface{}{}
+	}
+	return structure.FlattenJsonToString(picked)
+}
+
+func pickJSON(response, keys interface{}, keepMissing bool) interface{} {
+	switch k := keys.(type) {
+	case map[string]interface{}:
+		r, ok := response.(map[string]interface{})
+		if !ok {
+			return response
+		}
+		picked := map[string]interface{}{}
+		for key, v := range k {
+			if rv, ok := r[key]; ok {
+				picked[key] = pickJSON(rv, v, keepMissing)
+			} else if keepMissing {
+				picked[key] = v
+			}
+		}
+		return picked
+	case []interface{}:
+		r, ok := response.([]interface{})
+		if !ok {
+			return response
+		}
+		picked := []interface{}{}
+		for i, v := range k {
+			if i < len(r) {
+				picked = append(picked, pickJSON(r[i], v, keepMissing))
+			} else if keepMissing {
+				picked = append(picked, v)
+			}
+		}
+		return picked
+	default:
+		return response
+	}
+}
@@ -0,0 +1,73 @@
+package jfrogxray
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalWithExtraJSON(t *testing.T) {
+	v := map[string]interface{}{
+		"name":  "test",
+		"rules": []interface{}{map[string]interface{}{"name": "first"}, map[string]interface{}{"name": "second"}},
+	}
+
+	body, err := marshalWithExtraJSON(v, nil, `{"rules": [{}, {"new_field": true}], "new_setting": {"enabled": true}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"name":"test","new_setting":{"enabled":true},"rules":[{"name":"first"},{"name":"second","new_field":true}]}`
+	if string(body) != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+
+	// Fields only present in the body read from Xray are kept, but arrays come from the value being written
+	base := json.RawMessage(`{"name":"old","unknown":1,"rules":[{"name":"first"},{"name":"second"},{"name":"third"}]}`)
+	body, err = marshalWithExtraJSON(v, base, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"name":"test","rules":[{"name":"first"},{"name":"second"}],"unknown":1}`
+	if string(body) != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestFlattenExtraJSON(t *testing.T) {
+	response := json.RawMessage(`{"name":"test","new_setting":{"enabled":false,"other":2},"rules":[{"name":"first","new_field":true}]}`)
+
+	configured := `{"new_setting": {"enabled": true}, "rules": [{"new_field": true}], "missing": 1}`
+
+	extra, err := flattenExtraJSON(response, configured, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"new_setting":{"enabled":false},"rules":[{"new_field":true}]}`
+	if extra != expected {
+		t.Errorf("expected %s, got %s", expected, extra)
+	}
+
+	// Keys Xray doesn't return keep their configured value, as there is nothing to compare them with
+	extra, err = flattenExtraJSON(response, configured, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"missing":1,"new_setting":{"enabled":false},"rules":[{"new_field":true}]}`
+	if extra != expected {
+		t.Errorf("expected %s with missing keys kept, got %s", expected, extra)
+	}
+
+	if extra, _ := flattenExtraJSON(response, "", true); extra != "" {
+		t.Errorf("expected no extra JSON when none is configured, got %s", extra)
+	}
+}
+
+func TestValidateExtraJSON(t *testing.T) {
+	if _, errs := validateExtraJSON(`{"a": [1, 2]}`, "extra_json"); len(errs) > 0 {
+		t.Errorf("expected a JSON object to be valid, got %v", errs)
+	}
+	for _, v := range []string{`[1, 2]`, `"a"`, `{"a":`} {
+		if _, errs := validateExtraJSON(v, "extra_json"); len(errs) == 0 {
+			t.Errorf("expected %s to be invalid", v)
+		}
+	}
+}
@@ -0,0 +1,709 @@
+package jfrogxray
+
+// spdxLicenseListVersion is the version of the SPDX license list (https://spdx.org/licenses/) that
+// spdxLicenses was built from. Bump it together with the table below.
+const spdxLicenseListVersion = "3.27.0"
+
+// spdxLicenses holds every license identifier in the SPDX license list. Categories are a coarse
+// classification by license family, meant for building allow and deny lists rather than legal advice.
+var spdxLicenses = []licenseInfo{
+	{"0BSD", licenseCategoryPermissive, false},
+	{"3D-Slicer-1.0", licenseCategoryOther, false},
+	{"AAL", licenseCategoryOther, false},
+	{"Abstyles", licenseCategoryOther, false},
+	{"AdaCore-doc", licenseCategoryOther, false},
+	{"Adobe-2006", licenseCategoryOther, false},
+	{"Adobe-Display-PostScript", licenseCategoryOther, false},
+	{"Adobe-Glyph", licenseCategoryOther, false},
+	{"Adobe-Utopia", licenseCategoryOther, false},
+	{"ADSL", licenseCategoryOther, false},
+	{"AFL-1.1", licenseCategoryPermissive, false},
+	{"AFL-1.2", licenseCategoryPermissive, false},
+	{"AFL-2.0", licenseCategoryPermissive, false},
+	{"AFL-2.1", licenseCategoryPermissive, false},
+	{"AFL-3.0", licenseCategoryPermissive, false},
+	{"Afmparse", licenseCategoryOther, false},
+	{"AGPL-1.0", licenseCategoryCopyleft, true},
+	{"AGPL-1.0-only", licenseCategoryCopyleft, false},
+	{"AGPL-1.0-or-later", licenseCategoryCopyleft, false},
+	{"AGPL-3.0", licenseCategoryCopyleft, true},
+	{"AGPL-3.0-only", licenseCategoryCopyleft, false},
+	{"AGPL-3.0-or-later", licenseCategoryCopyleft, false},
+	{"Aladdin", licenseCategoryOther, false},
+	{"AMD-newlib", licenseCategoryOther, false},
+	{"AMDPLPA", licenseCategoryOther, false},
+	{"AML", licenseCategoryPermissive, false},
+	{"AML-glslang", licenseCategoryPermissive, false},
+	{"AMPAS", licenseCategoryOther, false},
+	{"ANTLR-PD", licenseCategoryOther, false},
+	{"ANTLR-PD-fallback", licenseCategoryOther, false},
+	{"any-OSI", licenseCategoryOther, false},
+	{"any-OSI-perl-modules", licenseCategoryOther, false},
+	{"Apache-1.0", licenseCategoryPermissive, false},
+	{"Apache-1.1", licenseCategoryPermissive, false},
+	{"Apache-2.0", licenseCategoryPermissive, false},
+	{"APAFML", licenseCategoryOther, false},
+	{"APL-1.0", licenseCategoryOther, false},
+	{"App-s2p", licenseCategoryOther, false},
+	{"APSL-1.0", licenseCategoryWeakCopyleft, false},
+	{"APSL-1.1", licenseCategoryWeakCopyleft, false},
+	{"APSL-1.2", licenseCategoryWeakCopyleft, false},
+	{"APSL-2.0", licenseCategoryWeakCopyleft, false},
+	{"Arphic-1999", licenseCategoryOther, false},
+	{"Artistic-1.0", licenseCategoryPermissive, false},
+	{"Artistic-1.0-cl8", licenseCategoryPermissive, false},
+	{"Artistic-1.0-Perl", licenseCategoryPermissive, false},
+	{"Artistic-2.0", licenseCategoryPermissive, false},
+	{"Artistic-dist", licenseCategoryPermissive, false},
+	{"Aspell-RU", licenseCategoryOther, false},
+	{"ASWF-Digital-Assets-1.0", licenseCategoryOther, false},
+	{"ASWF-Digital-Assets-1.1", licenseCategoryOther, false},
+	{"Baekmuk", licenseCategoryOther, false},
+	{"Bahyph", licenseCategoryOther, false},
+	{"Barr", licenseCategoryOther, false},
+	{"bcrypt-Solar-Designer", licenseCategoryPermissive, false},
+	{"Beerware", licenseCategoryPermissive, false},
+	{"Bitstream-Charter", licenseCategoryOther, false},
+	{"Bitstream-Vera", licenseCategoryOther, false},
+	{"BitTorrent-1.0", licenseCategoryOther, false},
+	{"BitTorrent-1.1", licenseCategoryOther, false},
+	{"blessing", licenseCategoryPublicDomain, false},
+	{"BlueOak-1.0.0", licenseCategoryPermissive, false},
+	{"Boehm-GC", licenseCategoryPermissive, false},
+	{"Boehm-GC-without-fee", licenseCategoryPermissive, false},
+	{"Borceux", licenseCategoryOther, false},
+	{"Brian-Gladman-2-Clause", licenseCategoryOther, false},
+	{"Brian-Gladman-3-Clause", licenseCategoryOther, false},
+	{"BSD-1-Clause", licenseCategoryPermissive, false},
+	{"BSD-2-Clause", licenseCategoryPermissive, false},
+	{"BSD-2-Clause-Darwin", licenseCategoryPermissive, false},
+	{"BSD-2-Clause-first-lines", licenseCategoryPermissive, false},
+	{"BSD-2-Clause-FreeBSD", licenseCategoryPermissive, true},
+	{"BSD-2-Clause-NetBSD", licenseCategoryPermissive, true},
+	{"BSD-2-Clause-Patent", licenseCategoryPermissive, false},
+	{"BSD-2-Clause-pkgconf-disclaimer", licenseCategoryPermissive, false},
+	{"BSD-2-Clause-Views", licenseCategoryPermissive, false},
+	{"BSD-3-Clause", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-acpica", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-Attribution", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-Clear", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-flex", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-HP", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-LBNL", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-Modification", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-No-Military-License", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-No-Nuclear-License", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-No-Nuclear-License-2014", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-No-Nuclear-Warranty", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-Open-MPI", licenseCategoryPermissive, false},
+	{"BSD-3-Clause-Sun", licenseCategoryPermissive, false},
+	{"BSD-4-Clause", licenseCategoryPermissive, false},
+	{"BSD-4-Clause-Shortened", licenseCategoryPermissive, false},
+	{"BSD-4-Clause-UC", licenseCategoryPermissive, false},
+	{"BSD-4.3RENO", licenseCategoryPermissive, false},
+	{"BSD-4.3TAHOE", licenseCategoryPermissive, false},
+	{"BSD-Advertising-Acknowledgement", licenseCategoryPermissive, false},
+	{"BSD-Attribution-HPND-disclaimer", licenseCategoryPermissive, false},
+	{"BSD-Inferno-Nettverk", licenseCategoryPermissive, false},
+	{"BSD-Protection", licenseCategoryPermissive, false},
+	{"BSD-Source-beginning-file", licenseCategoryPermissive, false},
+	{"BSD-Source-Code", licenseCategoryPermissive, false},
+	{"BSD-Systemics", licenseCategoryPermissive, false},
+	{"BSD-Systemics-W3Works", licenseCategoryPermissive, false},
+	{"BSL-1.0", licenseCategoryPermissive, false},
+	{"BUSL-1.1", licenseCategoryOther, false},
+	{"bzip2-1.0.5", licenseCategoryPermissive, true},
+	{"bzip2-1.0.6", licenseCategoryPermissive, false},
+	{"C-UDA-1.0", licenseCategoryOther, false},
+	{"CAL-1.0", licenseCategoryOther, false},
+	{"CAL-1.0-Combined-Work-Exception", licenseCategoryOther, false},
+	{"Caldera", licenseCategoryOther, false},
+	{"Caldera-no-preamble", licenseCategoryOther, false},
+	{"Catharon", licenseCategoryOther, false},
+	{"CATOSL-1.1", licenseCategoryOther, false},
+	{"CC-BY-1.0", licenseCategoryPermissive, false},
+	{"CC-BY-2.0", licenseCategoryPermissive, false},
+	{"CC-BY-2.5", licenseCategoryPermissive, false},
+	{"CC-BY-2.5-AU", licenseCategoryPermissive, false},
+	{"CC-BY-3.0", licenseCategoryPermissive, false},
+	{"CC-BY-3.0-AT", licenseCategoryPermissive, false},
+	{"CC-BY-3.0-AU", licenseCategoryPermissive, false},
+	{"CC-BY-3.0-DE", licenseCategoryPermissive, false},
+	{"CC-BY-3.0-IGO", licenseCategoryPermissive, false},
+	{"CC-BY-3.0-NL", licenseCategoryPermissive, false},
+	{"CC-BY-3.0-US", licenseCategoryPermissive, false},
+	{"CC-BY-4.0", licenseCategoryPermissive, false},
+	{"CC-BY-NC-1.0", licenseCategoryOther, false},
+	{"CC-BY-NC-2.0", licenseCategoryOther, false},
+	{"CC-BY-NC-2.5", licenseCategoryOther, false},
+	{"CC-BY-NC-3.0", licenseCategoryOther, false},
+	{"CC-BY-NC-3.0-DE", licenseCategoryOther, false},
+	{"CC-BY-NC-4.0", licenseCategoryOther, false},
+	{"CC-BY-NC-ND-1.0", licenseCategoryOther, false},
+	{"CC-BY-NC-ND-2.0", licenseCategoryOther, false},
+	{"CC-BY-NC-ND-2.5", licenseCategoryOther, false},
+	{"CC-BY-NC-ND-3.0", licenseCategoryOther, false},
+	{"CC-BY-NC-ND-3.0-DE", licenseCategoryOther, false},
+	{"CC-BY-NC-ND-3.0-IGO", licenseCategoryOther, false},
+	{"CC-BY-NC-ND-4.0", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-1.0", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-2.0", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-2.0-DE", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-2.0-FR", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-2.0-UK", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-2.5", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-3.0", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-3.0-DE", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-3.0-IGO", licenseCategoryOther, false},
+	{"CC-BY-NC-SA-4.0", licenseCategoryOther, false},
+	{"CC-BY-ND-1.0", licenseCategoryOther, false},
+	{"CC-BY-ND-2.0", licenseCategoryOther, false},
+	{"CC-BY-ND-2.5", licenseCategoryOther, false},
+	{"CC-BY-ND-3.0", licenseCategoryOther, false},
+	{"CC-BY-ND-3.0-DE", licenseCategoryOther, false},
+	{"CC-BY-ND-4.0", licenseCategoryOther, false},
+	{"CC-BY-SA-1.0", licenseCategoryCopyleft, false},
+	{"CC-BY-SA-2.0", licenseCategoryCopyleft, false},
+	{"CC-BY-SA-2.0-UK", licenseCategoryCopyleft, false},
+	{"CC-BY-SA-2.1-JP", licenseCategoryCopyleft, false},
+	{"CC-BY-SA-2.5", licenseCategoryCopyleft, false},
+	{"CC-BY-SA-3.0", licenseCategoryCopyleft, false},
+	{"CC-BY-SA-3.0-AT", licenseCategoryCopyleft, false},
+	{"CC-BY-SA-3.0-DE", licenseCategoryCopyleft, false},
+	{"CC-BY-SA-3.0-IGO", licenseCategoryCopyleft, false},
+	{"CC-BY-SA-4.0", licenseCategoryCopyleft, false},
+	{"CC-PDDC", licenseCategoryPublicDomain, false},
+	{"CC-PDM-1.0", licenseCategoryPublicDomain, false},
+	{"CC-SA-1.0", licenseCategoryOther, false},
+	{"CC0-1.0", licenseCategoryPublicDomain, false},
+	{"CDDL-1.0", licenseCategoryWeakCopyleft, false},
+	{"CDDL-1.1", licenseCategoryWeakCopyleft, false},
+	{"CDL-1.0", licenseCategoryOther, false},
+	{"CDLA-Permissive-1.0", licenseCategoryPermissive, false},
+	{"CDLA-Permissive-2.0", licenseCategoryPermissive, false},
+	{"CDLA-Sharing-1.0", licenseCategoryWeakCopyleft, false},
+	{"CECILL-1.0", licenseCategoryCopyleft, false},
+	{"CECILL-1.1", licenseCategoryCopyleft, false},
+	{"CECILL-2.0", licenseCategoryCopyleft, false},
+	{"CECILL-2.1", licenseCategoryCopyleft, false},
+	{"CECILL-B", licenseCategoryPermissive, false},
+	{"CECILL-C", licenseCategoryWeakCopyleft, false},
+	{"CERN-OHL-1.1", licenseCategoryCopyleft, false},
+	{"CERN-OHL-1.2", licenseCategoryCopyleft, false},
+	{"CERN-OHL-P-2.0", licenseCategoryOther, false},
+	{"CERN-OHL-S-2.0", licenseCategoryCopyleft, false},
+	{"CERN-OHL-W-2.0", licenseCategoryWeakCopyleft, false},
+	{"CFITSIO", licenseCategoryOther, false},
+	{"check-cvs", licenseCategoryOther, false},
+	{"checkmk", licenseCategoryOther, false},
+	{"ClArtistic", licenseCategoryOther, false},
+	{"Clips", licenseCategoryOther, false},
+	{"CMU-Mach", licenseCategoryPermissive, false},
+	{"CMU-Mach-nodoc", licenseCategoryPermissive, false},
+	{"CNRI-Jython", licenseCategoryOther, false},
+	{"CNRI-Python", licenseCategoryPermissive, false},
+	{"CNRI-Python-GPL-Compatible", licenseCategoryPermissive, false},
+	{"COIL-1.0", licenseCategoryOther, false},
+	{"Community-Spec-1.0", licenseCategoryOther, false},
+	{"Condor-1.1", licenseCategoryOther, false},
+	{"copyleft-next-0.3.0", licenseCategoryCopyleft, false},
+	{"copyleft-next-0.3.1", licenseCategoryCopyleft, false},
+	{"Cornell-Lossless-JPEG", licenseCategoryOther, false},
+	{"CPAL-1.0", licenseCategoryWeakCopyleft, false},
+	{"CPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"CPOL-1.02", licenseCategoryOther, false},
+	{"Cronyx", licenseCategoryOther, false},
+	{"Crossword", licenseCategoryOther, false},
+	{"CryptoSwift", licenseCategoryOther, false},
+	{"CrystalStacker", licenseCategoryOther, false},
+	{"CUA-OPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"Cube", licenseCategoryOther, false},
+	{"curl", licenseCategoryPermissive, false},
+	{"cve-tou", licenseCategoryOther, false},
+	{"D-FSL-1.0", licenseCategoryOther, false},
+	{"DEC-3-Clause", licenseCategoryOther, false},
+	{"diffmark", licenseCategoryOther, false},
+	{"DL-DE-BY-2.0", licenseCategoryOther, false},
+	{"DL-DE-ZERO-2.0", licenseCategoryOther, false},
+	{"DOC", licenseCategoryPermissive, false},
+	{"DocBook-DTD", licenseCategoryOther, false},
+	{"DocBook-Schema", licenseCategoryOther, false},
+	{"DocBook-Stylesheet", licenseCategoryOther, false},
+	{"DocBook-XML", licenseCategoryOther, false},
+	{"Dotseqn", licenseCategoryOther, false},
+	{"DRL-1.0", licenseCategoryOther, false},
+	{"DRL-1.1", licenseCategoryOther, false},
+	{"DSDP", licenseCategoryOther, false},
+	{"dtoa", licenseCategoryPermissive, false},
+	{"dvipdfm", licenseCategoryOther, false},
+	{"ECL-1.0", licenseCategoryPermissive, false},
+	{"ECL-2.0", licenseCategoryPermissive, false},
+	{"eCos-2.0", licenseCategoryCopyleft, true},
+	{"EFL-1.0", licenseCategoryPermissive, false},
+	{"EFL-2.0", licenseCategoryPermissive, false},
+	{"eGenix", licenseCategoryOther, false},
+	{"Elastic-2.0", licenseCategoryOther, false},
+	{"Entessa", licenseCategoryPermissive, false},
+	{"EPICS", licenseCategoryOther, false},
+	{"EPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"EPL-2.0", licenseCategoryWeakCopyleft, false},
+	{"ErlPL-1.1", licenseCategoryWeakCopyleft, false},
+	{"etalab-2.0", licenseCategoryPermissive, false},
+	{"EUDatagrid", licenseCategoryWeakCopyleft, false},
+	{"EUPL-1.0", licenseCategoryCopyleft, false},
+	{"EUPL-1.1", licenseCategoryCopyleft, false},
+	{"EUPL-1.2", licenseCategoryCopyleft, false},
+	{"Eurosym", licenseCategoryOther, false},
+	{"Fair", licenseCategoryPermissive, false},
+	{"FBM", licenseCategoryOther, false},
+	{"FDK-AAC", licenseCategoryOther, false},
+	{"Ferguson-Twofish", licenseCategoryOther, false},
+	{"Frameworx-1.0", licenseCategoryOther, false},
+	{"FreeBSD-DOC", licenseCategoryOther, false},
+	{"FreeImage", licenseCategoryOther, false},
+	{"FSFAP", licenseCategoryPermissive, false},
+	{"FSFAP-no-warranty-disclaimer", licenseCategoryPermissive, false},
+	{"FSFUL", licenseCategoryPermissive, false},
+	{"FSFULLR", licenseCategoryPermissive, false},
+	{"FSFULLRSD", licenseCategoryPermissive, false},
+	{"FSFULLRWD", licenseCategoryPermissive, false},
+	{"FSL-1.1-ALv2", licenseCategoryOther, false},
+	{"FSL-1.1-MIT", licenseCategoryOther, false},
+	{"FTL", licenseCategoryPermissive, false},
+	{"Furuseth", licenseCategoryOther, false},
+	{"fwlw", licenseCategoryOther, false},
+	{"Game-Programming-Gems", licenseCategoryOther, false},
+	{"GCR-docs", licenseCategoryOther, false},
+	{"GD", licenseCategoryOther, false},
+	{"generic-xts", licenseCategoryOther, false},
+	{"GFDL-1.1", licenseCategoryCopyleft, true},
+	{"GFDL-1.1-invariants-only", licenseCategoryCopyleft, false},
+	{"GFDL-1.1-invariants-or-later", licenseCategoryCopyleft, false},
+	{"GFDL-1.1-no-invariants-only", licenseCategoryCopyleft, false},
+	{"GFDL-1.1-no-invariants-or-later", licenseCategoryCopyleft, false},
+	{"GFDL-1.1-only", licenseCategoryCopyleft, false},
+	{"GFDL-1.1-or-later", licenseCategoryCopyleft, false},
+	{"GFDL-1.2", licenseCategoryCopyleft, true},
+	{"GFDL-1.2-invariants-only", licenseCategoryCopyleft, false},
+	{"GFDL-1.2-invariants-or-later", licenseCategoryCopyleft, false},
+	{"GFDL-1.2-no-invariants-only", licenseCategoryCopyleft, false},
+	{"GFDL-1.2-no-invariants-or-later", licenseCategoryCopyleft, false},
+	{"GFDL-1.2-only", licenseCategoryCopyleft, false},
+	{"GFDL-1.2-or-later", licenseCategoryCopyleft, false},
+	{"GFDL-1.3", licenseCategoryCopyleft, true},
+	{"GFDL-1.3-invariants-only", licenseCategoryCopyleft, false},
+	{"GFDL-1.3-invariants-or-later", licenseCategoryCopyleft, false},
+	{"GFDL-1.3-no-invariants-only", licenseCategoryCopyleft, false},
+	{"GFDL-1.3-no-invariants-or-later", licenseCategoryCopyleft, false},
+	{"GFDL-1.3-only", licenseCategoryCopyleft, false},
+	{"GFDL-1.3-or-later", licenseCategoryCopyleft, false},
+	{"Giftware", licenseCategoryOther, false},
+	{"GL2PS", licenseCategoryOther, false},
+	{"Glide", licenseCategoryOther, false},
+	{"Glulxe", licenseCategoryPermissive, false},
+	{"GLWTPL", licenseCategoryOther, false},
+	{"gnuplot", licenseCategoryOther, false},
+	{"GPL-1.0", licenseCategoryCopyleft, true},
+	{"GPL-1.0+", licenseCategoryCopyleft, true},
+	{"GPL-1.0-only", licenseCategoryCopyleft, false},
+	{"GPL-1.0-or-later", licenseCategoryCopyleft, false},
+	{"GPL-2.0", licenseCategoryCopyleft, true},
+	{"GPL-2.0+", licenseCategoryCopyleft, true},
+	{"GPL-2.0-only", licenseCategoryCopyleft, false},
+	{"GPL-2.0-or-later", licenseCategoryCopyleft, false},
+	{"GPL-2.0-with-autoconf-exception", licenseCategoryCopyleft, true},
+	{"GPL-2.0-with-bison-exception", licenseCategoryCopyleft, true},
+	{"GPL-2.0-with-classpath-exception", licenseCategoryCopyleft, true},
+	{"GPL-2.0-with-font-exception", licenseCategoryCopyleft, true},
+	{"GPL-2.0-with-GCC-exception", licenseCategoryCopyleft, true},
+	{"GPL-3.0", licenseCategoryCopyleft, true},
+	{"GPL-3.0+", licenseCategoryCopyleft, true},
+	{"GPL-3.0-only", licenseCategoryCopyleft, false},
+	{"GPL-3.0-or-later", licenseCategoryCopyleft, false},
+	{"GPL-3.0-with-autoconf-exception", licenseCategoryCopyleft, true},
+	{"GPL-3.0-with-GCC-exception", licenseCategoryCopyleft, true},
+	{"Graphics-Gems", licenseCategoryOther, false},
+	{"gSOAP-1.3b", licenseCategoryWeakCopyleft, false},
+	{"gtkbook", licenseCategoryOther, false},
+	{"Gutmann", licenseCategoryOther, false},
+	{"HaskellReport", licenseCategoryOther, false},
+	{"HDF5", licenseCategoryOther, false},
+	{"hdparm", licenseCategoryOther, false},
+	{"HIDAPI", licenseCategoryOther, false},
+	{"Hippocratic-2.1", licenseCategoryOther, false},
+	{"HP-1986", licenseCategoryOther, false},
+	{"HP-1989", licenseCategoryOther, false},
+	{"HPND", licenseCategoryPermissive, false},
+	{"HPND-DEC", licenseCategoryPermissive, false},
+	{"HPND-doc", licenseCategoryPermissive, false},
+	{"HPND-doc-sell", licenseCategoryPermissive, false},
+	{"HPND-export-US", licenseCategoryPermissive, false},
+	{"HPND-export-US-acknowledgement", licenseCategoryPermissive, false},
+	{"HPND-export-US-modify", licenseCategoryPermissive, false},
+	{"HPND-export2-US", licenseCategoryPermissive, false},
+	{"HPND-Fenneberg-Livingston", licenseCategoryPermissive, false},
+	{"HPND-INRIA-IMAG", licenseCategoryPermissive, false},
+	{"HPND-Intel", licenseCategoryPermissive, false},
+	{"HPND-Kevlin-Henney", licenseCategoryPermissive, false},
+	{"HPND-Markus-Kuhn", licenseCategoryPermissive, false},
+	{"HPND-merchantability-variant", licenseCategoryPermissive, false},
+	{"HPND-MIT-disclaimer", licenseCategoryPermissive, false},
+	{"HPND-Netrek", licenseCategoryPermissive, false},
+	{"HPND-Pbmplus", licenseCategoryPermissive, false},
+	{"HPND-sell-MIT-disclaimer-xserver", licenseCategoryPermissive, false},
+	{"HPND-sell-regexpr", licenseCategoryPermissive, false},
+	{"HPND-sell-variant", licenseCategoryPermissive, false},
+	{"HPND-sell-variant-MIT-disclaimer", licenseCategoryPermissive, false},
+	{"HPND-sell-variant-MIT-disclaimer-rev", licenseCategoryPermissive, false},
+	{"HPND-UC", licenseCategoryPermissive, false},
+	{"HPND-UC-export-US", licenseCategoryPermissive, false},
+	{"HTMLTIDY", licenseCategoryOther, false},
+	{"IBM-pibs", licenseCategoryOther, false},
+	{"ICU", licenseCategoryPermissive, false},
+	{"IEC-Code-Components-EULA", licenseCategoryOther, false},
+	{"IJG", licenseCategoryPermissive, false},
+	{"IJG-short", licenseCategoryPermissive, false},
+	{"ImageMagick", licenseCategoryPermissive, false},
+	{"iMatix", licenseCategoryOther, false},
+	{"Imlib2", licenseCategoryPermissive, false},
+	{"Info-ZIP", licenseCategoryPermissive, false},
+	{"Inner-Net-2.0", licenseCategoryOther, false},
+	{"InnoSetup", licenseCategoryOther, false},
+	{"Intel", licenseCategoryPermissive, false},
+	{"Intel-ACPI", licenseCategoryPermissive, false},
+	{"Interbase-1.0", licenseCategoryWeakCopyleft, false},
+	{"IPA", licenseCategoryOther, false},
+	{"IPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"ISC", licenseCategoryPermissive, false},
+	{"ISC-Veillard", licenseCategoryPublicDomain, false},
+	{"Jam", licenseCategoryOther, false},
+	{"JasPer-2.0", licenseCategoryPermissive, false},
+	{"jove", licenseCategoryOther, false},
+	{"JPL-image", licenseCategoryOther, false},
+	{"JPNIC", licenseCategoryOther, false},
+	{"JSON", licenseCategoryOther, false},
+	{"Kastrup", licenseCategoryOther, false},
+	{"Kazlib", licenseCategoryOther, false},
+	{"Knuth-CTAN", licenseCategoryOther, false},
+	{"LAL-1.2", licenseCategoryOther, false},
+	{"LAL-1.3", licenseCategoryOther, false},
+	{"Latex2e", licenseCategoryPermissive, false},
+	{"Latex2e-translated-notice", licenseCategoryPermissive, false},
+	{"Leptonica", licenseCategoryPermissive, false},
+	{"LGPL-2.0", licenseCategoryWeakCopyleft, true},
+	{"LGPL-2.0+", licenseCategoryWeakCopyleft, true},
+	{"LGPL-2.0-only", licenseCategoryWeakCopyleft, false},
+	{"LGPL-2.0-or-later", licenseCategoryWeakCopyleft, false},
+	{"LGPL-2.1", licenseCategoryWeakCopyleft, true},
+	{"LGPL-2.1+", licenseCategoryWeakCopyleft, true},
+	{"LGPL-2.1-only", licenseCategoryWeakCopyleft, false},
+	{"LGPL-2.1-or-later", licenseCategoryWeakCopyleft, false},
+	{"LGPL-3.0", licenseCategoryWeakCopyleft, true},
+	{"LGPL-3.0+", licenseCategoryWeakCopyleft, true},
+	{"LGPL-3.0-only", licenseCategoryWeakCopyleft, false},
+	{"LGPL-3.0-or-later", licenseCategoryWeakCopyleft, false},
+	{"LGPLLR", licenseCategoryWeakCopyleft, false},
+	{"Libpng", licenseCategoryPermissive, false},
+	{"libpng-1.6.35", licenseCategoryPermissive, false},
+	{"libpng-2.0", licenseCategoryPermissive, false},
+	{"libselinux-1.0", licenseCategoryPublicDomain, false},
+	{"libtiff", licenseCategoryPermissive, false},
+	{"libutil-David-Nugent", licenseCategoryPermissive, false},
+	{"LiLiQ-P-1.1", licenseCategoryOther, false},
+	{"LiLiQ-R-1.1", licenseCategoryOther, false},
+	{"LiLiQ-Rplus-1.1", licenseCategoryOther, false},
+	{"Linux-man-pages-1-para", licenseCategoryOther, false},
+	{"Linux-man-pages-copyleft", licenseCategoryOther, false},
+	{"Linux-man-pages-copyleft-2-para", licenseCategoryOther, false},
+	{"Linux-man-pages-copyleft-var", licenseCategoryOther, false},
+	{"Linux-OpenIB", licenseCategoryOther, false},
+	{"LOOP", licenseCategoryPublicDomain, false},
+	{"LPD-document", licenseCategoryOther, false},
+	{"LPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"LPL-1.02", licenseCategoryWeakCopyleft, false},
+	{"LPPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"LPPL-1.1", licenseCategoryWeakCopyleft, false},
+	{"LPPL-1.2", licenseCategoryWeakCopyleft, false},
+	{"LPPL-1.3a", licenseCategoryWeakCopyleft, false},
+	{"LPPL-1.3c", licenseCategoryWeakCopyleft, false},
+	{"lsof", licenseCategoryOther, false},
+	{"Lucida-Bitmap-Fonts", licenseCategoryOther, false},
+	{"LZMA-SDK-9.11-to-9.20", licenseCategoryOther, false},
+	{"LZMA-SDK-9.22", licenseCategoryOther, false},
+	{"Mackerras-3-Clause", licenseCategoryOther, false},
+	{"Mackerras-3-Clause-acknowledgment", licenseCategoryOther, false},
+	{"magaz", licenseCategoryOther, false},
+	{"mailprio", licenseCategoryPermissive, false},
+	{"MakeIndex", licenseCategoryOther, false},
+	{"man2html", licenseCategoryOther, false},
+	{"Martin-Birgmeier", licenseCategoryOther, false},
+	{"McPhee-slideshow", licenseCategoryOther, false},
+	{"metamail", licenseCategoryOther, false},
+	{"Minpack", licenseCategoryPermissive, false},
+	{"MIPS", licenseCategoryOther, false},
+	{"MirOS", licenseCategoryPermissive, false},
+	{"MIT", licenseCategoryPermissive, false},
+	{"MIT-0", licenseCategoryPermissive, false},
+	{"MIT-advertising", licenseCategoryPermissive, false},
+	{"MIT-Click", licenseCategoryPermissive, false},
+	{"MIT-CMU", licenseCategoryPermissive, false},
+	{"MIT-enna", licenseCategoryPermissive, false},
+	{"MIT-feh", licenseCategoryPermissive, false},
+	{"MIT-Festival", licenseCategoryPermissive, false},
+	{"MIT-Khronos-old", licenseCategoryPermissive, false},
+	{"MIT-Modern-Variant", licenseCategoryPermissive, false},
+	{"MIT-open-group", licenseCategoryPermissive, false},
+	{"MIT-testregex", licenseCategoryPermissive, false},
+	{"MIT-Wu", licenseCategoryPermissive, false},
+	{"MITNFA", licenseCategoryPermissive, false},
+	{"MMIXware", licenseCategoryOther, false},
+	{"Motosoto", licenseCategoryWeakCopyleft, false},
+	{"MPEG-SSG", licenseCategoryOther, false},
+	{"mpi-permissive", licenseCategoryOther, false},
+	{"mpich2", licenseCategoryPermissive, false},
+	{"MPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"MPL-1.1", licenseCategoryWeakCopyleft, false},
+	{"MPL-2.0", licenseCategoryWeakCopyleft, false},
+	{"MPL-2.0-no-copyleft-exception", licenseCategoryWeakCopyleft, false},
+	{"mplus", licenseCategoryOther, false},
+	{"MS-LPL", licenseCategoryOther, false},
+	{"MS-PL", licenseCategoryPermissive, false},
+	{"MS-RL", licenseCategoryWeakCopyleft, false},
+	{"MTLL", licenseCategoryPermissive, false},
+	{"MulanPSL-1.0", licenseCategoryPermissive, false},
+	{"MulanPSL-2.0", licenseCategoryPermissive, false},
+	{"Multics", licenseCategoryOther, false},
+	{"Mup", licenseCategoryPermissive, false},
+	{"NAIST-2003", licenseCategoryOther, false},
+	{"NASA-1.3", licenseCategoryPermissive, false},
+	{"Naumen", licenseCategoryPermissive, false},
+	{"NBPL-1.0", licenseCategoryOther, false},
+	{"NCBI-PD", licenseCategoryOther, false},
+	{"NCGL-UK-2.0", licenseCategoryOther, false},
+	{"NCL", licenseCategoryOther, false},
+	{"NCSA", licenseCategoryPermissive, false},
+	{"Net-SNMP", licenseCategoryPermissive, true},
+	{"NetCDF", licenseCategoryOther, false},
+	{"Newsletr", licenseCategoryOther, false},
+	{"NGPL", licenseCategoryOther, false},
+	{"ngrep", licenseCategoryOther, false},
+	{"NICTA-1.0", licenseCategoryOther, false},
+	{"NIST-PD", licenseCategoryPublicDomain, false},
+	{"NIST-PD-fallback", licenseCategoryPublicDomain, false},
+	{"NIST-Software", licenseCategoryOther, false},
+	{"NLOD-1.0", licenseCategoryPermissive, false},
+	{"NLOD-2.0", licenseCategoryPermissive, false},
+	{"NLPL", licenseCategoryOther, false},
+	{"Nokia", licenseCategoryWeakCopyleft, false},
+	{"NOSL", licenseCategoryOther, false},
+	{"Noweb", licenseCategoryOther, false},
+	{"NPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"NPL-1.1", licenseCategoryWeakCopyleft, false},
+	{"NPOSL-3.0", licenseCategoryCopyleft, false},
+	{"NRL", licenseCategoryOther, false},
+	{"NTIA-PD", licenseCategoryOther, false},
+	{"NTP", licenseCategoryPermissive, false},
+	{"NTP-0", licenseCategoryPermissive, false},
+	{"Nunit", licenseCategoryOther, true},
+	{"O-UDA-1.0", licenseCategoryOther, false},
+	{"OAR", licenseCategoryOther, false},
+	{"OCCT-PL", licenseCategoryOther, false},
+	{"OCLC-2.0", licenseCategoryWeakCopyleft, false},
+	{"ODbL-1.0", licenseCategoryCopyleft, false},
+	{"ODC-By-1.0", licenseCategoryOther, false},
+	{"OFFIS", licenseCategoryOther, false},
+	{"OFL-1.0", licenseCategoryPermissive, false},
+	{"OFL-1.0-no-RFN", licenseCategoryPermissive, false},
+	{"OFL-1.0-RFN", licenseCategoryPermissive, false},
+	{"OFL-1.1", licenseCategoryPermissive, false},
+	{"OFL-1.1-no-RFN", licenseCategoryPermissive, false},
+	{"OFL-1.1-RFN", licenseCategoryPermissive, false},
+	{"OGC-1.0", licenseCategoryOther, false},
+	{"OGDL-Taiwan-1.0", licenseCategoryOther, false},
+	{"OGL-Canada-2.0", licenseCategoryPermissive, false},
+	{"OGL-UK-1.0", licenseCategoryCopyleft, false},
+	{"OGL-UK-2.0", licenseCategoryCopyleft, false},
+	{"OGL-UK-3.0", licenseCategoryCopyleft, false},
+	{"OGTSL", licenseCategoryOther, false},
+	{"OLDAP-1.1", licenseCategoryPermissive, false},
+	{"OLDAP-1.2", licenseCategoryPermissive, false},
+	{"OLDAP-1.3", licenseCategoryPermissive, false},
+	{"OLDAP-1.4", licenseCategoryPermissive, false},
+	{"OLDAP-2.0", licenseCategoryPermissive, false},
+	{"OLDAP-2.0.1", licenseCategoryPermissive, false},
+	{"OLDAP-2.1", licenseCategoryPermissive, false},
+	{"OLDAP-2.2", licenseCategoryPermissive, false},
+	{"OLDAP-2.2.1", licenseCategoryPermissive, false},
+	{"OLDAP-2.2.2", licenseCategoryPermissive, false},
+	{"OLDAP-2.3", licenseCategoryPermissive, false},
+	{"OLDAP-2.4", licenseCategoryPermissive, false},
+	{"OLDAP-2.5", licenseCategoryPermissive, false},
+	{"OLDAP-2.6", licenseCategoryPermissive, false},
+	{"OLDAP-2.7", licenseCategoryPermissive, false},
+	{"OLDAP-2.8", licenseCategoryPermissive, false},
+	{"OLFL-1.3", licenseCategoryWeakCopyleft, false},
+	{"OML", licenseCategoryPermissive, false},
+	{"OpenPBS-2.3", licenseCategoryOther, false},
+	{"OpenSSL", licenseCategoryPermissive, false},
+	{"OpenSSL-standalone", licenseCategoryPermissive, false},
+	{"OpenVision", licenseCategoryOther, false},
+	{"OPL-1.0", licenseCategoryOther, false},
+	{"OPL-UK-3.0", licenseCategoryOther, false},
+	{"OPUBL-1.0", licenseCategoryOther, false},
+	{"OSET-PL-2.1", licenseCategoryWeakCopyleft, false},
+	{"OSL-1.0", licenseCategoryCopyleft, false},
+	{"OSL-1.1", licenseCategoryCopyleft, false},
+	{"OSL-2.0", licenseCategoryCopyleft, false},
+	{"OSL-2.1", licenseCategoryCopyleft, false},
+	{"OSL-3.0", licenseCategoryCopyleft, false},
+	{"PADL", licenseCategoryOther, false},
+	{"Parity-6.0.0", licenseCategoryCopyleft, false},
+	{"Parity-7.0.0", licenseCategoryCopyleft, false},
+	{"PDDL-1.0", licenseCategoryPublicDomain, false},
+	{"PHP-3.0", licenseCategoryPermissive, false},
+	{"PHP-3.01", licenseCategoryPermissive, false},
+	{"Pixar", licenseCategoryOther, false},
+	{"pkgconf", licenseCategoryOther, false},
+	{"Plexus", licenseCategoryPermissive, false},
+	{"pnmstitch", licenseCategoryOther, false},
+	{"PolyForm-Noncommercial-1.0.0", licenseCategoryOther, false},
+	{"PolyForm-Small-Business-1.0.0", licenseCategoryOther, false},
+	{"PostgreSQL", licenseCategoryPermissive, false},
+	{"PPL", licenseCategoryOther, false},
+	{"PSF-2.0", licenseCategoryPermissive, false},
+	{"psfrag", licenseCategoryOther, false},
+	{"psutils", licenseCategoryOther, false},
+	{"Python-2.0", licenseCategoryPermissive, false},
+	{"Python-2.0.1", licenseCategoryPermissive, false},
+	{"python-ldap", licenseCategoryOther, false},
+	{"Qhull", licenseCategoryOther, false},
+	{"QPL-1.0", licenseCategoryCopyleft, false},
+	{"QPL-1.0-INRIA-2004", licenseCategoryCopyleft, false},
+	{"radvd", licenseCategoryOther, false},
+	{"Rdisc", licenseCategoryOther, false},
+	{"RHeCos-1.1", licenseCategoryOther, false},
+	{"RPL-1.1", licenseCategoryCopyleft, false},
+	{"RPL-1.5", licenseCategoryCopyleft, false},
+	{"RPSL-1.0", licenseCategoryCopyleft, false},
+	{"RSA-MD", licenseCategoryOther, false},
+	{"RSCPL", licenseCategoryOther, false},
+	{"Ruby", licenseCategoryPermissive, false},
+	{"Ruby-pty", licenseCategoryPermissive, false},
+	{"SAX-PD", licenseCategoryPublicDomain, false},
+	{"SAX-PD-2.0", licenseCategoryPublicDomain, false},
+	{"Saxpath", licenseCategoryPermissive, false},
+	{"SCEA", licenseCategoryOther, false},
+	{"SchemeReport", licenseCategoryOther, false},
+	{"Sendmail", licenseCategoryOther, false},
+	{"Sendmail-8.23", licenseCategoryOther, false},
+	{"Sendmail-Open-Source-1.1", licenseCategoryOther, false},
+	{"SGI-B-1.0", licenseCategoryPermissive, false},
+	{"SGI-B-1.1", licenseCategoryPermissive, false},
+	{"SGI-B-2.0", licenseCategoryPermissive, false},
+	{"SGI-OpenGL", licenseCategoryOther, false},
+	{"SGP4", licenseCategoryOther, false},
+	{"SHL-0.5", licenseCategoryOther, false},
+	{"SHL-0.51", licenseCategoryOther, false},
+	{"SimPL-2.0", licenseCategoryCopyleft, false},
+	{"SISSL", licenseCategoryWeakCopyleft, false},
+	{"SISSL-1.2", licenseCategoryWeakCopyleft, false},
+	{"SL", licenseCategoryOther, false},
+	{"Sleepycat", licenseCategoryCopyleft, false},
+	{"SMAIL-GPL", licenseCategoryOther, false},
+	{"SMLNJ", licenseCategoryPermissive, false},
+	{"SMPPL", licenseCategoryOther, false},
+	{"SNIA", licenseCategoryOther, false},
+	{"snprintf", licenseCategoryPermissive, false},
+	{"SOFA", licenseCategoryOther, false},
+	{"softSurfer", licenseCategoryOther, false},
+	{"Soundex", licenseCategoryOther, false},
+	{"Spencer-86", licenseCategoryPermissive, false},
+	{"Spencer-94", licenseCategoryPermissive, false},
+	{"Spencer-99", licenseCategoryPermissive, false},
+	{"SPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"ssh-keyscan", licenseCategoryPermissive, false},
+	{"SSH-OpenSSH", licenseCategoryPermissive, false},
+	{"SSH-short", licenseCategoryPermissive, false},
+	{"SSLeay-standalone", licenseCategoryOther, false},
+	{"SSPL-1.0", licenseCategoryOther, false},
+	{"StandardML-NJ", licenseCategoryPermissive, true},
+	{"SugarCRM-1.1.3", licenseCategoryWeakCopyleft, false},
+	{"SUL-1.0", licenseCategoryOther, false},
+	{"Sun-PPP", licenseCategoryOther, false},
+	{"Sun-PPP-2000", licenseCategoryOther, false},
+	{"SunPro", licenseCategoryOther, false},
+	{"SWL", licenseCategoryPermissive, false},
+	{"swrule", licenseCategoryOther, false},
+	{"Symlinks", licenseCategoryOther, false},
+	{"TAPR-OHL-1.0", licenseCategoryCopyleft, false},
+	{"TCL", licenseCategoryPermissive, false},
+	{"TCP-wrappers", licenseCategoryPermissive, false},
+	{"TermReadKey", licenseCategoryOther, false},
+	{"TGPPL-1.0", licenseCategoryOther, false},
+	{"ThirdEye", licenseCategoryOther, false},
+	{"threeparttable", licenseCategoryOther, false},
+	{"TMate", licenseCategoryOther, false},
+	{"TORQUE-1.1", licenseCategoryOther, false},
+	{"TOSL", licenseCategoryOther, false},
+	{"TPDL", licenseCategoryOther, false},
+	{"TPL-1.0", licenseCategoryOther, false},
+	{"TrustedQSL", licenseCategoryOther, false},
+	{"TTWL", licenseCategoryPermissive, false},
+	{"TTYP0", licenseCategoryOther, false},
+	{"TU-Berlin-1.0", licenseCategoryPermissive, false},
+	{"TU-Berlin-2.0", licenseCategoryPermissive, false},
+	{"Ubuntu-font-1.0", licenseCategoryOther, false},
+	{"UCAR", licenseCategoryOther, false},
+	{"UCL-1.0", licenseCategoryPermissive, false},
+	{"ulem", licenseCategoryOther, false},
+	{"UMich-Merit", licenseCategoryOther, false},
+	{"Unicode-3.0", licenseCategoryPermissive, false},
+	{"Unicode-DFS-2015", licenseCategoryPermissive, false},
+	{"Unicode-DFS-2016", licenseCategoryPermissive, false},
+	{"Unicode-TOU", licenseCategoryPermissive, false},
+	{"UnixCrypt", licenseCategoryPermissive, false},
+	{"Unlicense", licenseCategoryPublicDomain, false},
+	{"Unlicense-libtelnet", licenseCategoryPermissive, false},
+	{"Unlicense-libwhirlpool", licenseCategoryPermissive, false},
+	{"UPL-1.0", licenseCategoryPermissive, false},
+	{"URT-RLE", licenseCategoryOther, false},
+	{"Vim", licenseCategoryCopyleft, false},
+	{"VOSTROM", licenseCategoryOther, false},
+	{"VSL-1.0", licenseCategoryPermissive, false},
+	{"W3C", licenseCategoryPermissive, false},
+	{"W3C-19980720", licenseCategoryPermissive, false},
+	{"W3C-20150513", licenseCategoryPermissive, false},
+	{"w3m", licenseCategoryPermissive, false},
+	{"Watcom-1.0", licenseCategoryOther, false},
+	{"Widget-Workshop", licenseCategoryOther, false},
+	{"Wsuipa", licenseCategoryOther, false},
+	{"WTFPL", licenseCategoryPermissive, false},
+	{"wwl", licenseCategoryOther, false},
+	{"wxWindows", licenseCategoryCopyleft, true},
+	{"X11", licenseCategoryPermissive, false},
+	{"X11-distribute-modifications-variant", licenseCategoryPermissive, false},
+	{"X11-swapped", licenseCategoryPermissive, false},
+	{"Xdebug-1.03", licenseCategoryPermissive, false},
+	{"Xerox", licenseCategoryPermissive, false},
+	{"Xfig", licenseCategoryOther, false},
+	{"XFree86-1.1", licenseCategoryPermissive, false},
+	{"xinetd", licenseCategoryPermissive, false},
+	{"xkeyboard-config-Zinoviev", licenseCategoryOther, false},
+	{"xlock", licenseCategoryPermissive, false},
+	{"Xnet", licenseCategoryPermissive, false},
+	{"xpp", licenseCategoryPermissive, false},
+	{"XSkat", licenseCategoryOther, false},
+	{"xzoom", licenseCategoryOther, false},
+	{"YPL-1.0", licenseCategoryWeakCopyleft, false},
+	{"YPL-1.1", licenseCategoryWeakCopyleft, false},
+	{"Zed", licenseCategoryPermissive, false},
+	{"Zeeff", licenseCategoryPermissive, false},
+	{"Zend-2.0", licenseCategoryPermissive, false},
+	{"Zimbra-1.3", licenseCategoryOther, false},
+	{"Zimbra-1.4", licenseCategoryOther, false},
+	{"Zlib", licenseCategoryPermissive, false},
+	{"zlib-acknowledgement", licenseCategoryPermissive, false},
+	{"ZPL-1.1", licenseCategoryPermissive, false},
+	{"ZPL-2.0", licenseCategoryPermissive, false},
+	{"ZPL-2.1", licenseCategoryPermissive, false},
+}
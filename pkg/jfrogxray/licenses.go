@@ -0,0 +1,123 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agext/levenshtein"
+)
+
+const (
+	licenseCategoryPermissive    = "permissive"
+	licenseCategoryWeakCopyleft  = "weak_copyleft"
+	licenseCategoryCopyleft      = "copyleft"
+	licenseCategoryPublicDomain  = "public_domain"
+	licenseCategoryOther         = "other"
+	licenseSourceSPDX            = "spdx"
+	licenseSourceXray            = "xray"
+	maxLicenseSuggestionDistance = 3
+)
+
+var validLicenseCategories = []string{
+	licenseCategoryPermissive,
+	licenseCategoryWeakCopyleft,
+	licenseCategoryCopyleft,
+	licenseCategoryPublicDomain,
+	licenseCategoryOther,
+}
+
+type licenseInfo struct {
+	ID         string
+	Category   string
+	Deprecated bool
+}
+
+// xrayLicenses are the generic license names Xray reports for components whose license could only
+// be narrowed down to a family. They aren't SPDX identifiers but Xray accepts them in policies.
+var xrayLicenses = []licenseInfo{
+	{"Apache", licenseCategoryPermissive, false},
+	{"BSD", licenseCategoryPermissive, false},
+	{"CDDL", licenseCategoryWeakCopyleft, false},
+	{"EPL", licenseCategoryWeakCopyleft, false},
+	{"GPL", licenseCategoryCopyleft, false},
+	{"LGPL", licenseCategoryWeakCopyleft, false},
+	{"MPL", licenseCategoryWeakCopyleft, false},
+	{"Public Domain", licenseCategoryPublicDomain, false},
+}
+
+// licenseIndex maps lower-cased license names to their catalogue entry
+var licenseIndex = buildLicenseIndex()
+
+func buildLicenseIndex() map[string]licenseInfo {
+	index := make(map[string]licenseInfo, len(spdxLicenses)+len(xrayLicenses))
+	for _, l := range spdxLicenses {
+		index[strings.ToLower(l.ID)] = l
+	}
+	for _, l := range xrayLicenses {
+		index[strings.ToLower(l.ID)] = l
+	}
+	return index
+}
+
+func lookupLicense(name string) (licenseInfo, bool) {
+	l, ok := licenseIndex[strings.ToLower(name)]
+	return l, ok
+}
+
+// suggestLicense returns the catalogued license closest to name, or "" if nothing is close enough
+func suggestLicense(name string) string {
+	given := strings.ToLower(name)
+	suggestion, best := "", maxLicenseSuggestionDistance
+	for key, l := range licenseIndex {
+		dist := levenshtein.Distance(given, key, nil)
+		// Ties are broken alphabetically so the suggestion doesn't depend on map ordering
+		if dist < best || (dist == best && suggestion != "" && l.ID < suggestion) {
+			suggestion, best = l.ID, dist
+		}
+	}
+	return suggestion
+}
+
+// licensesInCategories returns the IDs of every catalogued license in the given categories that isn't deprecated
+func licensesInCategories(categories []string) []string {
+	wanted := map[string]bool{}
+	for _, c := range categories {
+		wanted[c] = true
+	}
+
+	ids := []string{}
+	for _, l := range append(append([]licenseInfo{}, spdxLicenses...), xrayLicenses...) {
+		if wanted[l.Category] && !l.Deprecated {
+			ids = append(ids, l.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// validateLicenseName only fails when the name is certainly wrong, i.e. a catalogued license in the wrong case.
+// Names that aren't catalogued may be custom licenses defined in Xray, or licenses added to SPDX after the embedded
+// list, so they only produce a warning.
+func validateLicenseName(v interface{}, k string) (ws []string, es []error) {
+	name := v.(string)
+
+	l, ok := lookupLicense(name)
+	if !ok {
+		if suggestion := suggestLicense(name); suggestion != "" {
+			ws = append(ws, fmt.Sprintf("%s: %q is not a known SPDX or Xray license, did you mean %q? Ignore this if it's a custom license defined in Xray", k, name, suggestion))
+		} else {
+			ws = append(ws, fmt.Sprintf("%s: %q is not a known SPDX or Xray license (SPDX license list %s). Ignore this if it's a custom license defined in Xray", k, name, spdxLicenseListVersion))
+		}
+		return
+	}
+	if l.ID != name {
+		// Xray matches license names exactly, so a difference in case means the rule never fires
+		es = append(es, fmt.Errorf("%s: license names are case sensitive, did you mean %q?", k, l.ID))
+		return
+	}
+	if l.Deprecated {
+		ws = append(ws, fmt.Sprintf("%s: %q is deprecated in SPDX license list %s", k, name, spdxLicenseListVersion))
+	}
+	return
+}
@@ -0,0 +1,93 @@
+package jfrogxray
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestLicenseCatalogue_unique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, l := range append(append([]licenseInfo{}, spdxLicenses...), xrayLicenses...) {
+		key := strings.ToLower(l.ID)
+		if seen[key] {
+			t.Errorf("license %q is listed more than once", l.ID)
+		}
+		seen[key] = true
+	}
+}
+
+func TestValidateLicenseName(t *testing.T) {
+	// The licenses used by the acceptance tests must all pass
+	for _, v := range []string{"0BSD", "BSD-4-Clause", "diffmark", "Apache-2.0", "Public Domain"} {
+		if _, errs := validateLicenseName(v, "banned_licenses.0"); len(errs) > 0 {
+			t.Errorf("expected %q to be a valid license, got %v", v, errs)
+		}
+	}
+
+	// Only a catalogued license in the wrong case is certainly wrong
+	if _, errs := validateLicenseName("mit", "banned_licenses.0"); len(errs) != 1 || !strings.Contains(errs[0].Error(), "did you mean \"MIT\"") {
+		t.Errorf("expected \"mit\" to fail with a suggestion of \"MIT\", got %v", errs)
+	}
+
+	// Anything else that isn't catalogued may be a custom license, so it's only a warning
+	cases := map[string]string{
+		"Apache-2.O":                "did you mean \"Apache-2.0\"",
+		"GLP-3.0":                   "did you mean \"GPL-3.0\"",
+		"not-a-real-license-at-all": "is not a known SPDX or Xray license",
+		"ACME-Internal-1.0":         "custom license defined in Xray",
+	}
+	for v, expected := range cases {
+		ws, errs := validateLicenseName(v, "banned_licenses.0")
+		if len(errs) > 0 || len(ws) != 1 || !strings.Contains(ws[0], expected) {
+			t.Errorf("expected %q to warn with %q, got %v %v", v, expected, ws, errs)
+		}
+	}
+
+	ws, errs := validateLicenseName("GPL-2.0", "banned_licenses.0")
+	if len(errs) > 0 || len(ws) != 1 {
+		t.Errorf("expected the deprecated GPL-2.0 to produce one warning and no errors, got %v %v", ws, errs)
+	}
+}
+
+func TestLicensesInCategories(t *testing.T) {
+	ids := licensesInCategories([]string{licenseCategoryCopyleft, licenseCategoryPublicDomain})
+	for _, expected := range []string{"GPL-3.0-only", "AGPL-3.0-only", "GPL", "Public Domain", "Unlicense"} {
+		found := false
+		for _, id := range ids {
+			found = found || id == expected
+		}
+		if !found {
+			t.Errorf("expected %q in the copyleft and public domain licenses", expected)
+		}
+	}
+	for _, id := range ids {
+		if l, _ := lookupLicense(id); l.Deprecated || (l.Category != licenseCategoryCopyleft && l.Category != licenseCategoryPublicDomain) {
+			t.Errorf("unexpected license %q", id)
+		}
+	}
+}
+
+func TestDataSourceXrayLicenses_category(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceXrayLicenses().Schema, map[string]interface{}{
+		"category": licenseCategoryCopyleft,
+	})
+	if err := dataSourceXrayLicensesRead(d, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := d.Get("ids").([]interface{})
+	if len(ids) == 0 {
+		t.Fatal("expected at least one copyleft license")
+	}
+	for _, id := range ids {
+		l, _ := lookupLicense(id.(string))
+		if l.Category != licenseCategoryCopyleft || l.Deprecated {
+			t.Errorf("unexpected license %q in the copyleft list", l.ID)
+		}
+	}
+	if d.Get("version").(string) != spdxLicenseListVersion {
+		t.Errorf("expected version %s, got %s", spdxLicenseListVersion, d.Get("version"))
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/xero-oss/go-xray/xray"
 
 	"github.com/atlassian/go-artifactory/v2/artifactory/transport"
 )
 
+// watchMutexKV serializes read-modify-write updates to a single watch, keyed by watch name
+var watchMutexKV = mutexkv.NewMutexKV()
+
 // Xray Provider that supports configuration via username+password or a token
 // Supported resources are (for now) watches and policies
 func Provider() terraform.ResourceProvider {
@@ -45,11 +48,27 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"xray_watch":  resourceXrayWatch(),
-			"xray_policy": resourceXrayPolicy(),
+			"xray_watch":                   resourceXrayWatch(),
+			"xray_policy":                  resourceXrayPolicy(),
+			"xray_watch_policy_assignment": resourceXrayWatchPolicyAssignment(),
+			"xray_api_object":              resourceXrayAPIObject(),
+			"xray_build_scan":              resourceXrayBuildScan(),
+			"xray_custom_issue":            resourceXrayCustomIssue(),
+			"xray_system_settings":         resourceXraySystemSettings(),
+			"xray_db_sync_settings":        resourceXrayDBSyncSettings(),
+			"xray_offline_db_update":       resourceXrayOfflineDBUpdate(),
 		},
 
-		DataSourcesMap: map[string]*schema.Resource{},
+		DataSourcesMap: map[string]*schema.Resource{
+			"xray_licenses":          dataSourceXrayLicenses(),
+			"xray_policy_document":   dataSourceXrayPolicyDocument(),
+			"xray_violations":        dataSourceXrayViolations(),
+			"xray_component_summary": dataSourceXrayComponentSummary(),
+			"xray_artifact_summary":  dataSourceXrayArtifactSummary(),
+			"xray_graph_scan":        dataSourceXrayGraphScan(),
+			"xray_sbom":              dataSourceXraySBOM(),
+			"xray_vulnerability":     dataSourceXrayVulnerability(),
+		},
 
 		ConfigureFunc: providerConfigure,
 	}
@@ -66,22 +85,25 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	accessToken := d.Get("access_token").(string)
 
 	var client *http.Client
+	var authenticate func(*http.Request)
 	if username != "" && password != "" {
 		tp := transport.BasicAuth{
 			Username: username,
 			Password: password,
 		}
 		client = tp.Client()
+		authenticate = func(req *http.Request) { req.SetBasicAuth(username, password) }
 	} else if accessToken != "" {
 		tp := transport.AccessTokenAuth{
 			AccessToken: accessToken,
 		}
 		client = tp.Client()
+		authenticate = func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+accessToken) }
 	} else {
 		return nil, fmt.Errorf("either [username, password] or [access_token] must be set to use provider")
 	}
 
-	rt, err := xray.NewClient(d.Get("url").(string), client)
+	rt, err := newXrayClient(d.Get("url").(string), client, authenticate)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,224 @@
+package jfrogxray
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+const apiObjectIDPlaceholder = "{id}"
+
+var validAPIObjectMethods = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodGet, http.MethodDelete}
+
+// Manages any JSON object behind the Xray REST API, for endpoints this provider doesn't have a resource for yet.
+// Only the fields set in body are compared with what Xray returns, so fields Xray fills in don't show up as drift.
+func resourceXrayAPIObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceXrayAPIObjectCreate,
+		Read:   resourceXrayAPIObjectRead,
+		Update: resourceXrayAPIObjectUpdate,
+		Delete: resourceXrayAPIObjectDelete,
+
+		CustomizeDiff: resourceXrayAPIObjectCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAPIPath,
+			},
+			"object_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAPIPath,
+			},
+			"body": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateExtraJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				StateFunc: func(v interface{}) string {
+					s, _ := structure.NormalizeJsonString(v)
+					return s
+				},
+			},
+			"id_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "id",
+			},
+			"create_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      http.MethodPost,
+				ValidateFunc: validation.StringInSlice(validAPIObjectMethods, false),
+			},
+			"read_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      http.MethodGet,
+				ValidateFunc: validation.StringInSlice(validAPIObjectMethods, false),
+			},
+			"update_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      http.MethodPut,
+				ValidateFunc: validation.StringInSlice(append([]string{""}, validAPIObjectMethods...), false),
+			},
+			"delete_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      http.MethodDelete,
+				ValidateFunc: validation.StringInSlice(validAPIObjectMethods, false),
+			},
+			"response": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func validateAPIPath(v interface{}, k string) (ws []string, es []error) {
+	if !strings.HasPrefix(v.(string), "/api/") {
+		es = append(es, fmt.Errorf("%s must start with /api/, got %q", k, v))
+	}
+	return
+}
+
+// Endpoints without an update method are replaced instead
+func resourceXrayAPIObjectCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.Get("update_method").(string) == "" && d.HasChange("body") {
+		return d.ForceNew("body")
+	}
+	return nil
+}
+
+// apiObjectPath returns the path of a single object, which defaults to the collection path followed by the ID
+func apiObjectPath(d *schema.ResourceData) string {
+	id := url.PathEscape(d.Id())
+	if p := d.Get("object_path").(string); p != "" {
+		return strings.Replace(p, apiObjectIDPlaceholder, id, -1)
+	}
+	return strings.TrimSuffix(d.Get("path").(string), "/") + "/" + id
+}
+
+// findAPIObjectID follows a dot separated path, e.g. "info.id", through a JSON document
+func findAPIObjectID(doc json.RawMessage, path string) (string, bool) {
+	var v interface{}
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return "", false
+	}
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		v = m[key]
+	}
+
+	switch id := v.(type) {
+	case string:
+		return id, id != ""
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+func expandAPIObjectBody(d *schema.ResourceData) (map[string]interface{}, error) {
+	return structure.ExpandJsonFromString(d.Get("body").(string))
+}
+
+func resourceXrayAPIObjectCreate(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	body, err := expandAPIObjectBody(d)
+	if err != nil {
+		return err
+	}
+
+	var response json.RawMessage
+	if _, err := c.doJSON(context.Background(), d.Get("create_method").(string), d.Get("path").(string), body, &response); err != nil {
+		return err
+	}
+
+	// Many endpoints only answer with a message, in which case the ID has to be part of the object itself
+	idAttribute := d.Get("id_attribute").(string)
+	id, ok := findAPIObjectID(response, idAttribute)
+	if !ok {
+		request, _ := json.Marshal(body)
+		if id, ok = findAPIObjectID(request, idAttribute); !ok {
+			return fmt.Errorf("unable to find %q in the response or the body to use as the ID, got %s", idAttribute, response)
+		}
+	}
+
+	d.SetId(id)
+	return resourceXrayAPIObjectRead(d, meta)
+}
+
+func resourceXrayAPIObjectRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	var response json.RawMessage
+	resp, err := c.doJSON(context.Background(), d.Get("read_method").(string), apiObjectPath(d), nil, &response)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		log.Printf("[WARN] Xray object (%s) not found, removing from state", apiObjectPath(d))
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// Some endpoints answer with no content at all when there's nothing to show
+	if len(response) == 0 {
+		response = json.RawMessage("{}")
+	}
+
+	body, err := flattenExtraJSON(response, d.Get("body").(string), false)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("body", body); err != nil {
+		return err
+	}
+	if err := d.Set("response", string(response)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceXrayAPIObjectUpdate(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	body, err := expandAPIObjectBody(d)
+	if err != nil {
+		return err
+	}
+	if _, err := c.doJSON(context.Background(), d.Get("update_method").(string), apiObjectPath(d), body, nil); err != nil {
+		return err
+	}
+
+	return resourceXrayAPIObjectRead(d, meta)
+}
+
+func resourceXrayAPIObjectDelete(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	resp, err := c.doJSON(context.Background(), d.Get("delete_method").(string), apiObjectPath(d), nil, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,174 @@
+package jfrogxray
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAPIObject_basic(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_api_object.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayAPIObject("PATCH", "first")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "1"),
+					// created_by is set by the server and only shows up in the response
+					resource.TestCheckResourceAttr(resourceName, "body", `{"name":"first","settings":{"enabled":true}}`),
+					resource.TestCheckResourceAttrSet(resourceName, "response"),
+					func(*terraform.State) error {
+						if v := server.object("1")["created_by"]; v != "test-user" {
+							return fmt.Errorf("expected the object to be created, got %v", server.object("1"))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: server.edit(func() {
+					server.objects["1"]["settings"] = map[string]interface{}{"enabled": false}
+				}),
+				Config:             server.config(testAccXrayAPIObject("PATCH", "first")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: server.config(testAccXrayAPIObject("PATCH", "second")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "1"),
+					resource.TestCheckResourceAttr(resourceName, "body", `{"name":"second","settings":{"enabled":true}}`),
+				),
+			},
+			{
+				PreConfig: server.edit(func() {
+					delete(server.objects, "1")
+				}),
+				Config:             server.config(testAccXrayAPIObject("PATCH", "second")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccAPIObject_missingField(t *testing.T) {
+	server := newTestXrayServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayAPIObject("PATCH", "first")),
+			},
+			{
+				// A field that was set and is gone from the object is drift too
+				PreConfig: server.edit(func() {
+					delete(server.objects["1"], "settings")
+				}),
+				Config:             server.config(testAccXrayAPIObject("PATCH", "first")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccAPIObject_emptyResponse(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_api_object.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayAPIObject("PATCH", "first")),
+			},
+			{
+				// The server answers with no content, which reads as an empty object
+				PreConfig: server.edit(func() {
+					server.objects["1"] = map[string]interface{}{}
+				}),
+				Config:             server.config(testAccXrayAPIObject("PATCH", "first")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: server.config(testAccXrayAPIObject("PATCH", "first")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "body", `{"name":"first","settings":{"enabled":true}}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIObject_noUpdate(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_api_object.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayAPIObject("", "first")),
+				Check:  resource.TestCheckResourceAttr(resourceName, "id", "1"),
+			},
+			{
+				Config: server.config(testAccXrayAPIObject("", "second")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "2"),
+					func(*terraform.State) error {
+						if server.object("1") != nil {
+							return fmt.Errorf("expected the old object to be deleted")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestFindAPIObjectID(t *testing.T) {
+	doc := json.RawMessage(`{"id": 12, "info": {"id": "abc", "count": 1.5}, "list": [1]}`)
+
+	cases := map[string]string{"id": "12", "info.id": "abc", "info.count": "1.5"}
+	for path, expected := range cases {
+		if id, ok := findAPIObjectID(doc, path); !ok || id != expected {
+			t.Errorf("expected %q at %s, got %q", expected, path, id)
+		}
+	}
+	for _, path := range []string{"missing", "info", "list", "id.nested"} {
+		if id, ok := findAPIObjectID(doc, path); ok {
+			t.Errorf("expected no ID at %s, got %q", path, id)
+		}
+	}
+	if _, ok := findAPIObjectID(json.RawMessage(`Policy created successfully`), "id"); ok {
+		t.Error("expected no ID in a response that isn't JSON")
+	}
+}
+
+func testAccXrayAPIObject(updateMethod, name string) string {
+	return fmt.Sprintf(`
+resource "xray_api_object" "test" {
+	path          = "/api/v1/test/objects"
+	id_attribute  = "info.id"
+	update_method = "%s"
+	body          = jsonencode({
+		name     = "%s"
+		settings = { enabled = true }
+	})
+}
+`, updateMethod, name)
+}
@@ -0,0 +1,255 @@
+package jfrogxray
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Scans a build, like `jf build-scan`. The scan runs when the resource is created, and its result is kept in the
+// state, so a new build number or a rescan means a new resource.
+func resourceXrayBuildScan() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceXrayBuildScanCreate,
+		Read:   resourceXrayBuildScanRead,
+		Update: resourceXrayBuildScanUpdate,
+		Delete: resourceXrayBuildScanDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"build_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"build_number": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"project": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"rescan": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"fail_on_build_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"fail_build": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"more_details_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"violations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"severity": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"summary": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issue_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"watch_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fix_versions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"impacted_artifacts": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"matched_policies": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"policy": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"rule": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"is_blocking": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func flattenBuildViolations(violations []xrayBuildViolation) []interface{} {
+	l := make([]interface{}, 0, len(violations))
+	for _, v := range violations {
+		policies := make([]interface{}, 0, len(v.Policies))
+		for _, p := range v.Policies {
+			policies = append(policies, map[string]interface{}{
+				"policy":      p.Policy,
+				"rule":        p.Rule,
+				"is_blocking": p.IsBlocking,
+			})
+		}
+
+		l = append(l, map[string]interface{}{
+			"type":               v.Type,
+			"severity":           v.Severity,
+			"summary":            v.Summary,
+			"description":        v.Description,
+			"issue_id":           v.IssueID,
+			"watch_name":         v.WatchName,
+			"created":            v.Created,
+			"fix_versions":       v.FixVersions,
+			"impacted_artifacts": v.ImpactedArtifacts,
+			"matched_policies":   policies,
+		})
+	}
+	return l
+}
+
+// buildScanError lists the violations of blocking policies, which are the ones that made Xray fail the build
+func buildScanError(result *xrayBuildScanResult) error {
+	issues := []string{}
+	for _, v := range result.Violations {
+		for _, p := range v.Policies {
+			if p.IsBlocking {
+				issues = append(issues, fmt.Sprintf("%s (%s, policy %q)", v.IssueID, v.Severity, p.Policy))
+				break
+			}
+		}
+	}
+	return fmt.Errorf("Xray failed build %s/%s: %s, see %s", result.BuildName, result.BuildNumber, strings.Join(issues, ", "), result.MoreDetailsURL)
+}
+
+func resourceXrayBuildScanCreate(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+	ctx := context.Background()
+
+	name := d.Get("build_name").(string)
+	number := d.Get("build_number").(string)
+	project := d.Get("project").(string)
+
+	req := xrayBuildScanRequest{
+		BuildName:   name,
+		BuildNumber: number,
+		Project:     project,
+		Rescan:      d.Get("rescan").(bool),
+	}
+	if _, err := c.scanBuild(ctx, req); err != nil {
+		return err
+	}
+
+	// Xray answers with 202 and no status, or a pending status, until the scan is done
+	raw, err := waitForScan(fmt.Sprintf("build %s/%s", name, number), d.Timeout(schema.TimeoutCreate), func() (interface{}, string, error) {
+		result, resp, err := c.getBuildScanResult(ctx, name, number, project)
+		if err != nil {
+			return nil, "", err
+		}
+		switch {
+		case resp.StatusCode == http.StatusAccepted, result.Status == "", strings.EqualFold(result.Status, "pending"), strings.EqualFold(result.Status, "in_progress"):
+			return result, "IN_PROGRESS", nil
+		case strings.EqualFold(result.Status, "completed"):
+			return result, "DONE", nil
+		default:
+			return nil, "", fmt.Errorf("Xray failed to scan build %s/%s: %s %s", name, number, result.Status, result.Info)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	result := raw.(*xrayBuildScanResult)
+
+	// The build failed, so there is nothing to keep in the state, and the next apply scans it again
+	if result.FailBuild && d.Get("fail_on_build_failure").(bool) {
+		return buildScanError(result)
+	}
+
+	if err := d.Set("fail_build", result.FailBuild); err != nil {
+		return err
+	}
+	if err := d.Set("more_details_url", result.MoreDetailsURL); err != nil {
+		return err
+	}
+	if err := d.Set("violations", flattenBuildViolations(result.Violations)); err != nil {
+		return err
+	}
+
+	d.SetId(buildScanID(project, name, number))
+	return nil
+}
+
+// buildScanID joins the project, name and number of the build. The parts are escaped so that a "/" in a build name
+// can't be mistaken for a separator. Builds outside of a project have no project part.
+func buildScanID(project, name, number string) string {
+	parts := []string{url.PathEscape(name), url.PathEscape(number)}
+	if project != "" {
+		parts = append([]string{url.PathEscape(project)}, parts...)
+	}
+	return strings.Join(parts, "/")
+}
+
+// The result is that of the scan made when the resource was created, so there is nothing to refresh
+func resourceXrayBuildScanRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// Only fail_on_build_failure can change, which only matters for a new scan
+func resourceXrayBuildScanUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceXrayBuildScanRead(d, meta)
+}
+
+// Xray keeps the scan of the build, this only removes it from the state
+func resourceXrayBuildScanDelete(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
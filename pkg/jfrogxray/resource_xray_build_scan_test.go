@@ -0,0 +1,134 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBuildScan_basic(t *testing.T) {
+	fastScanPolling(t)
+	server := newTestXrayServer(t)
+	server.builds["app/1"] = &testBuild{polls: 2, result: testBuildScanResult("app", "1", false)}
+	resourceName := "xray_build_scan.test"
+
+	scans := func(expected int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if scans := server.builds["app/1"].scans; scans != expected {
+				return fmt.Errorf("expected the build to be scanned %d times, got %d", expected, scans)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayBuildScan("app", "1", false)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "app/1"),
+					resource.TestCheckResourceAttr(resourceName, "fail_build", "false"),
+					resource.TestCheckResourceAttr(resourceName, "more_details_url", "https://xray.example.com/ui/builds/app/1"),
+					resource.TestCheckResourceAttr(resourceName, "violations.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "violations.0.issue_id", "XRAY-191834"),
+					resource.TestCheckResourceAttr(resourceName, "violations.0.fix_versions.0", "2.17.1"),
+					resource.TestCheckResourceAttr(resourceName, "violations.0.matched_policies.0.is_blocking", "false"),
+					scans(1),
+				),
+			},
+			{
+				// Failing the apply only matters for the next scan
+				Config: server.config(testAccXrayBuildScan("app", "1", true)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "fail_on_build_failure", "true"),
+					scans(1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBuildScan_failBuild(t *testing.T) {
+	fastScanPolling(t)
+	server := newTestXrayServer(t)
+	server.builds["app/2"] = &testBuild{result: testBuildScanResult("app", "2", true)}
+	resourceName := "xray_build_scan.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config:      server.config(testAccXrayBuildScan("app", "2", true)),
+				ExpectError: regexp.MustCompile(`Xray failed build app/2: XRAY-191834 \(Critical, policy "block-critical"\)`),
+			},
+			{
+				Config: server.config(testAccXrayBuildScan("app", "2", false)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "fail_build", "true"),
+					resource.TestCheckResourceAttr(resourceName, "violations.0.matched_policies.0.is_blocking", "true"),
+				),
+			},
+			{
+				Config:      server.config(testAccXrayBuildScan("app", "3", false)),
+				ExpectError: regexp.MustCompile(`Build app/3 not found`),
+			},
+		},
+	})
+}
+
+func TestAccBuildScan_project(t *testing.T) {
+	fastScanPolling(t)
+	server := newTestXrayServer(t)
+	server.builds["team/app/1"] = &testBuild{result: testBuildScanResult("team/app", "1", false)}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+resource "xray_build_scan" "test" {
+	build_name   = "team/app"
+	build_number = "1"
+	project      = "ops"
+}
+`),
+				// The "/" in the build name mustn't read as a separator
+				Check: resource.TestCheckResourceAttr("xray_build_scan.test", "id", "ops/team%2Fapp/1"),
+			},
+		},
+	})
+}
+
+func testBuildScanResult(name, number string, failBuild bool) map[string]interface{} {
+	return map[string]interface{}{
+		"build_name":       name,
+		"build_number":     number,
+		"status":           "completed",
+		"more_details_url": fmt.Sprintf("https://xray.example.com/ui/builds/%s/%s", name, number),
+		"fail_build":       failBuild,
+		"violations": []interface{}{map[string]interface{}{
+			"type":               "security",
+			"severity":           "Critical",
+			"summary":            "Log4Shell",
+			"issue_id":           "XRAY-191834",
+			"watch_name":         "builds",
+			"fix_versions":       []string{"2.17.1"},
+			"impacted_artifacts": []string{"app-1.0.jar"},
+			"policies":           []interface{}{map[string]interface{}{"policy": "block-critical", "rule": "critical", "is_blocking": failBuild}},
+		}},
+	}
+}
+
+func testAccXrayBuildScan(name, number string, failOnBuildFailure bool) string {
+	return fmt.Sprintf(`
+resource "xray_build_scan" "test" {
+	build_name            = "%s"
+	build_number          = "%s"
+	fail_on_build_failure = %t
+}
+`, name, number, failOnBuildFailure)
+}
@@ -0,0 +1,246 @@
+package jfrogxray
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Manages an issue of our own in the Xray database, e.g. one found in an internal library. Watches and policies
+// treat it like any other issue.
+func resourceXrayCustomIssue() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceXrayCustomIssueCreate,
+		Read:   resourceXrayCustomIssueRead,
+		Update: resourceXrayCustomIssueUpdate,
+		Delete: resourceXrayCustomIssueDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"issue_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateIssueType,
+				DiffSuppressFunc: suppressCaseInsensitiveDiff,
+			},
+			"provider_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"package_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validatePackageType,
+				DiffSuppressFunc: suppressCaseInsensitiveDiff,
+			},
+			"severity": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateSeverity,
+				DiffSuppressFunc: suppressCaseInsensitiveDiff,
+			},
+			"summary": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"component": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"vulnerable_versions": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validatePackageVersion,
+							},
+						},
+						"fixed_versions": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"cve": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringMatch(
+								regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`),
+								"must be a CVE ID, e.g. CVE-2021-44228",
+							),
+						},
+						"cvss_v2": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"cvss_v3": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"source": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandCustomIssue(d *schema.ResourceData) *xrayIssueEvent {
+	event := &xrayIssueEvent{
+		ID:          d.Get("issue_id").(string),
+		Type:        d.Get("type").(string),
+		Provider:    d.Get("provider_name").(string),
+		PackageType: d.Get("package_type").(string),
+		Severity:    d.Get("severity").(string),
+		Summary:     d.Get("summary").(string),
+		Description: d.Get("description").(string),
+	}
+
+	for _, raw := range d.Get("component").([]interface{}) {
+		m := raw.(map[string]interface{})
+		event.Components = append(event.Components, xrayIssueEventComponent{
+			ID:                 m["id"].(string),
+			VulnerableVersions: expandStringSlice(m["vulnerable_versions"].([]interface{})),
+			FixedVersions:      expandStringSlice(m["fixed_versions"].([]interface{})),
+		})
+	}
+	for _, raw := range d.Get("cve").([]interface{}) {
+		m := raw.(map[string]interface{})
+		event.CVEs = append(event.CVEs, xrayIssueEventCVE{
+			CVE:    m["id"].(string),
+			CVSSV2: m["cvss_v2"].(string),
+			CVSSV3: m["cvss_v3"].(string),
+		})
+	}
+	for _, raw := range d.Get("source").([]interface{}) {
+		m := raw.(map[string]interface{})
+		event.Sources = append(event.Sources, xrayIssueEventSource{
+			Name:     m["name"].(string),
+			URL:      m["url"].(string),
+			SourceID: m["source_id"].(string),
+		})
+	}
+
+	return event
+}
+
+func resourceXrayCustomIssueCreate(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	event := expandCustomIssue(d)
+	if _, err := c.createIssueEvent(context.Background(), event); err != nil {
+		return err
+	}
+
+	d.SetId(event.ID)
+	return resourceXrayCustomIssueRead(d, meta)
+}
+
+func resourceXrayCustomIssueRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	event, resp, err := c.getIssueEvent(context.Background(), d.Id())
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		log.Printf("[WARN] Xray issue (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	cves := make([]interface{}, 0, len(event.CVEs))
+	for _, cve := range event.CVEs {
+		cves = append(cves, map[string]interface{}{
+			"id":      cve.CVE,
+			"cvss_v2": cve.CVSSV2,
+			"cvss_v3": cve.CVSSV3,
+		})
+	}
+
+	fields := map[string]interface{}{
+		"issue_id":      d.Id(),
+		"type":          event.Type,
+		"provider_name": event.Provider,
+		"package_type":  event.PackageType,
+		"severity":      event.Severity,
+		"summary":       event.Summary,
+		"description":   event.Description,
+		"component":     flattenIssueEventComponents(event.Components),
+		"cve":           cves,
+		"source":        flattenIssueEventSources(event.Sources),
+	}
+	for k, v := range fields {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceXrayCustomIssueUpdate(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	if _, err := c.updateIssueEvent(context.Background(), d.Id(), expandCustomIssue(d)); err != nil {
+		return err
+	}
+	return resourceXrayCustomIssueRead(d, meta)
+}
+
+func resourceXrayCustomIssueDelete(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	resp, err := c.deleteIssueEvent(context.Background(), d.Id())
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccCustomIssue_basic(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_custom_issue.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayCustomIssue("High", "2.0.0")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "ACME-2021-0001"),
+					// Xray capitalises the type, which shouldn't show up as a diff
+					resource.TestCheckResourceAttr(resourceName, "type", "Security"),
+					resource.TestCheckResourceAttr(resourceName, "severity", "High"),
+					resource.TestCheckResourceAttr(resourceName, "component.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "component.0.vulnerable_versions.0", "[1.0.0,2.0.0)"),
+					resource.TestCheckResourceAttr(resourceName, "component.0.fixed_versions.0", "2.0.0"),
+					resource.TestCheckResourceAttr(resourceName, "cve.0.id", "CVE-2021-99999"),
+					resource.TestCheckResourceAttr(resourceName, "source.0.name", "ACME security team"),
+				),
+			},
+			{
+				Config: server.config(testAccXrayCustomIssue("Critical", "2.0.1")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "severity", "Critical"),
+					resource.TestCheckResourceAttr(resourceName, "component.0.fixed_versions.0", "2.0.1"),
+					func(*terraform.State) error {
+						server.mu.Lock()
+						defer server.mu.Unlock()
+						if v := server.events["ACME-2021-0001"]["severity"]; v != "Critical" {
+							return fmt.Errorf("expected the issue to be updated, got severity %v", v)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:            server.config(testAccXrayCustomIssue("Critical", "2.0.1")),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: server.edit(func() {
+					delete(server.events, "ACME-2021-0001")
+				}),
+				Config:             server.config(testAccXrayCustomIssue("Critical", "2.0.1")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccCustomIssue_invalid(t *testing.T) {
+	server := newTestXrayServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+resource "xray_custom_issue" "test" {
+	issue_id      = "ACME-2021-0002"
+	type          = "malware"
+	provider_name = "ACME"
+	package_type  = "npm"
+	severity      = "Low"
+	summary       = "Not a known issue type"
+
+	component {
+		id                  = "acme-widgets"
+		vulnerable_versions = ["< 1.0.0"]
+	}
+}
+`),
+				ExpectError: regexp.MustCompile(`expected type to be one of`),
+			},
+		},
+	})
+}
+
+func testAccXrayCustomIssue(severity, fixedVersion string) string {
+	return fmt.Sprintf(`
+resource "xray_custom_issue" "test" {
+	issue_id      = "ACME-2021-0001"
+	type          = "security"
+	provider_name = "ACME"
+	package_type  = "maven"
+	severity      = "%s"
+	summary       = "Remote code execution in ACME widgets"
+	description   = "Deserialising a widget runs arbitrary code."
+
+	component {
+		id                  = "com.acme:widgets"
+		vulnerable_versions = ["[1.0.0,2.0.0)"]
+		fixed_versions      = ["%s"]
+	}
+
+	cve {
+		id      = "CVE-2021-99999"
+		cvss_v3 = "9.8/CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"
+	}
+
+	source {
+		name = "ACME security team"
+		url  = "https://security.acme.example/ACME-2021-0001"
+	}
+}
+`, severity, fixedVersion)
+}
@@ -0,0 +1,136 @@
+package jfrogxray
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	v1 "github.com/xero-oss/go-xray/xray/v1"
+)
+
+const dbSyncSettingsID = "xray_db_sync_settings"
+
+// Manages how Xray keeps its database up to date. Like xray_system_settings, the settings always exist, and settings
+// that aren't configured keep their value in Xray.
+func resourceXrayDBSyncSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceXrayDBSyncSettingsUpdate,
+		Read:   resourceXrayDBSyncSettingsRead,
+		Update: resourceXrayDBSyncSettingsUpdate,
+		Delete: resourceXrayDBSyncSettingsDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: importSingletonState(dbSyncSettingsID),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"sync_time": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`),
+					"must be a time of day in the 24-hour HH:mm format, e.g. 23:00",
+				),
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"online", "offline"}, false),
+			},
+			"use_proxy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"connection_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ssl_insecure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceXrayDBSyncSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+	ctx := context.Background()
+
+	syncTime, _, err := c.getDBSyncTime(ctx)
+	if err != nil {
+		return err
+	}
+	settings, _, err := c.getDBSyncSettings(ctx)
+	if err != nil {
+		return err
+	}
+	params, _, err := c.V1.Configuration.GetSystemParameters(ctx)
+	if err != nil {
+		return err
+	}
+
+	mode := "online"
+	if settings.OfflineMode != nil && *settings.OfflineMode {
+		mode = "offline"
+	}
+
+	fields := map[string]interface{}{
+		"sync_time":          syncTime.DBSyncUpdatesTime,
+		"mode":               mode,
+		"use_proxy":          settings.UseProxy,
+		"connection_timeout": settings.ConnectionTimeoutSeconds,
+		"ssl_insecure":       params.SslInsecure,
+	}
+	for k, v := range fields {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceXrayDBSyncSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+	ctx := context.Background()
+
+	if v := getOptionalString(d, "sync_time"); v != nil && *v != "" {
+		if _, err := c.updateDBSyncTime(ctx, &xrayDBSyncTime{DBSyncUpdatesTime: *v}); err != nil {
+			return err
+		}
+	}
+
+	settings := &xrayDBSyncSettings{
+		UseProxy:                 getOptionalBool(d, "use_proxy"),
+		ConnectionTimeoutSeconds: getOptionalInt(d, "connection_timeout"),
+	}
+	if v := getOptionalString(d, "mode"); v != nil && *v != "" {
+		offline := *v == "offline"
+		settings.OfflineMode = &offline
+	}
+	if _, err := c.updateDBSyncSettings(ctx, settings); err != nil {
+		return err
+	}
+
+	// The connection to the database sync service is configured with the rest of the system parameters
+	if v := getOptionalBool(d, "ssl_insecure"); v != nil {
+		if _, err := c.V1.Configuration.UpdateSystemParameters(ctx, &v1.SystemParameters{SslInsecure: v}); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(dbSyncSettingsID)
+	return resourceXrayDBSyncSettingsRead(d, meta)
+}
+
+// Xray always has these settings, so destroying the resource only stops managing them
+func resourceXrayDBSyncSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
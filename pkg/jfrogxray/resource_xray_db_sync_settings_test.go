@@ -0,0 +1,116 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccDBSyncSettings_basic(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_db_sync_settings.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+resource "xray_db_sync_settings" "test" {
+	sync_time = "23:30"
+	use_proxy = true
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "xray_db_sync_settings"),
+					resource.TestCheckResourceAttr(resourceName, "sync_time", "23:30"),
+					resource.TestCheckResourceAttr(resourceName, "use_proxy", "true"),
+					// Settings that aren't configured are read from Xray
+					resource.TestCheckResourceAttr(resourceName, "mode", "online"),
+					resource.TestCheckResourceAttr(resourceName, "connection_timeout", "60"),
+					resource.TestCheckResourceAttr(resourceName, "ssl_insecure", "false"),
+				),
+			},
+			{
+				Config: server.config(`
+resource "xray_db_sync_settings" "test" {
+	sync_time          = "23:30"
+	mode               = "offline"
+	use_proxy          = false
+	connection_timeout = 120
+	ssl_insecure       = true
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "mode", "offline"),
+					resource.TestCheckResourceAttr(resourceName, "use_proxy", "false"),
+					resource.TestCheckResourceAttr(resourceName, "connection_timeout", "120"),
+					resource.TestCheckResourceAttr(resourceName, "ssl_insecure", "true"),
+					func(*terraform.State) error {
+						server.mu.Lock()
+						defer server.mu.Unlock()
+						// Only ssl_insecure is managed, the other system parameters are left alone
+						if v := server.systemParameters["maxDiskDataUsage"]; v != 80 {
+							return fmt.Errorf("expected the other system parameters to be kept, got %v", server.systemParameters)
+						}
+						if v := server.dbSync["offline_mode"]; v != true {
+							return fmt.Errorf("expected offline mode, got %v", server.dbSync)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: server.config(`
+resource "xray_db_sync_settings" "test" {
+	sync_time          = "23:30"
+	mode               = "offline"
+	use_proxy          = false
+	connection_timeout = 120
+	ssl_insecure       = true
+}
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "db_sync",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: server.edit(func() {
+					server.dbSyncTime = "02:00"
+				}),
+				Config: server.config(`
+resource "xray_db_sync_settings" "test" {
+	sync_time          = "23:30"
+	mode               = "offline"
+	use_proxy          = false
+	connection_timeout = 120
+	ssl_insecure       = true
+}
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccDBSyncSettings_invalidTime(t *testing.T) {
+	server := newTestXrayServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+resource "xray_db_sync_settings" "test" {
+	sync_time = "24:00"
+}
+`),
+				ExpectError: regexp.MustCompile(`must be a time of day in the 24-hour HH:mm format`),
+			},
+		},
+	})
+}
@@ -0,0 +1,146 @@
+package jfrogxray
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Imports an offline database update bundle into an air-gapped Xray. The import runs when the resource is created,
+// and a new bundle, told apart by its checksum, means a new import.
+func resourceXrayOfflineDBUpdate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceXrayOfflineDBUpdateCreate,
+		Read:   resourceXrayOfflineDBUpdateRead,
+		Delete: resourceXrayOfflineDBUpdateDelete,
+
+		CustomizeDiff: offlineDBUpdateBundleDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"file_path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// offlineDBUpdateBundleDiff imports the bundle again when the file at file_path changes
+func offlineDBUpdateBundleDiff(d *schema.ResourceDiff, meta interface{}) error {
+	sum, err := fileSHA256(d.Get("file_path").(string))
+	if err != nil {
+		return fmt.Errorf("error reading the offline update bundle: %s", err)
+	}
+	if d.Id() == "" {
+		return d.SetNew("sha256", sum)
+	}
+	if sum != d.Get("sha256").(string) {
+		if err := d.SetNew("sha256", sum); err != nil {
+			return err
+		}
+		return d.ForceNew("sha256")
+	}
+	return nil
+}
+
+func resourceXrayOfflineDBUpdateCreate(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+	ctx := context.Background()
+
+	path := d.Get("file_path").(string)
+	bundle, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error reading the offline update bundle: %s", err)
+	}
+	defer bundle.Close()
+	info, err := bundle.Stat()
+	if err != nil {
+		return fmt.Errorf("error reading the offline update bundle: %s", err)
+	}
+
+	// The checksum is that of the bundle Xray got, even if the file changed since the plan
+	h := sha256.New()
+	update, _, err := c.uploadOfflineDBUpdate(ctx, io.TeeReader(bundle, h), info.Size())
+	if err != nil {
+		return err
+	}
+
+	id := update.ID
+	conf := &resource.StateChangeConf{
+		Pending: []string{"PENDING", "IN_PROGRESS"},
+		Target:  []string{"COMPLETED"},
+		Refresh: func() (interface{}, string, error) {
+			current, _, err := c.getOfflineDBUpdate(ctx, id)
+			if err != nil {
+				return nil, "", err
+			}
+			status := strings.ToUpper(current.Status)
+			if status == "FAILED" {
+				return nil, "", fmt.Errorf("Xray failed to import %s: %s", path, current.Error)
+			}
+			return current, status, nil
+		},
+		Timeout:      d.Timeout(schema.TimeoutCreate),
+		PollInterval: scanPollInterval,
+	}
+	raw, err := conf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for Xray to import the offline update bundle: %s", err)
+	}
+
+	if err := d.Set("sha256", hex.EncodeToString(h.Sum(nil))); err != nil {
+		return err
+	}
+	if err := d.Set("status", strings.ToLower(raw.(*xrayOfflineDBUpdate).Status)); err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return nil
+}
+
+// The import happened when the resource was created, so there is nothing to refresh
+func resourceXrayOfflineDBUpdateRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// Xray keeps the imported data, this only removes the import from the state
+func resourceXrayOfflineDBUpdateDelete(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
@@ -0,0 +1,88 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccOfflineDBUpdate_basic(t *testing.T) {
+	fastScanPolling(t)
+	server := newTestXrayServer(t)
+	resourceName := "xray_offline_db_update.test"
+	bundle := filepath.Join(t.TempDir(), "xray-update.zip")
+	writeBundle := func(content string) {
+		if err := ioutil.WriteFile(bundle, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeBundle("PK first bundle")
+
+	config := server.config(fmt.Sprintf(`
+resource "xray_offline_db_update" "test" {
+	file_path = %q
+}
+`, bundle))
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "1"),
+					resource.TestCheckResourceAttr(resourceName, "status", "completed"),
+					resource.TestCheckResourceAttr(resourceName, "sha256", "34c41ea4ee78022cfec26ef742ec3746b6c20578d0a11c577cd876dc5a78d162"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				// A new bundle at the same path is imported again
+				PreConfig: func() { writeBundle("PK second bundle") },
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "2"),
+					func(*terraform.State) error {
+						server.mu.Lock()
+						defer server.mu.Unlock()
+						if len(server.offlineUpdates) != 2 || string(server.offlineUpdates[1].bundle) != "PK second bundle" {
+							return fmt.Errorf("expected the second bundle to be uploaded, got %d uploads", len(server.offlineUpdates))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig:   func() { writeBundle("not a zip archive") },
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Xray failed to import .*xray-update.zip: the bundle isn't a zip archive`),
+			},
+		},
+	})
+}
+
+func TestAccOfflineDBUpdate_missingFile(t *testing.T) {
+	server := newTestXrayServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+resource "xray_offline_db_update" "test" {
+	file_path = "does-not-exist.zip"
+}
+`),
+				ExpectError: regexp.MustCompile(`error reading the offline update bundle`),
+			},
+		},
+	})
+}
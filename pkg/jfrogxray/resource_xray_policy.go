@@ -5,8 +5,13 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/xero-oss/go-xray/xray"
 	v1 "github.com/xero-oss/go-xray/xray/v1"
 )
@@ -18,6 +23,8 @@ func resourceXrayPolicy() *schema.Resource {
 		Update: resourceXrayPolicyUpdate,
 		Delete: resourceXrayPolicyDelete,
 
+		CustomizeDiff: resourceXrayPolicyCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -26,11 +33,11 @@ func resourceXrayPolicy() *schema.Resource {
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validatePolicyType,
 			},
 			"description": {
 				Type:     schema.TypeString,
@@ -48,125 +55,285 @@ func resourceXrayPolicy() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"extra_json": extraJSONSchema(),
+			"force_detach": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 
-			"rules": {
+			"rules": policyRulesSchema(),
+
+			// The licenses that license categories stand for, so that changes to them show up in the plan
+			"expanded_licenses": {
 				Type:     schema.TypeList,
-				Required: true,
+				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"name": {
+						"rule": {
 							Type:     schema.TypeString,
-							Required: true,
+							Computed: true,
 						},
-						"priority": {
-							Type:     schema.TypeInt,
-							Required: true,
+						"banned_licenses": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
-
-						"criteria": {
+						"allowed_licenses": {
 							Type:     schema.TypeList,
-							Required: true,
-							MinItems: 1,
-							MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									// Security criteria
-									"min_severity": {
-										Type:          schema.TypeString,
-										Optional:      true,
-										ConflictsWith: []string{"rules.criteria.0.allow_unknown", "rules.criteria.0.banned_licenses", "rules.criteria.0.allowed_licenses", "rules.criteria.0.cvss_range"},
-									},
-									"cvss_range": {
-										Type:          schema.TypeList,
-										Optional:      true,
-										ConflictsWith: []string{"rules.criteria.0.allow_unknown", "rules.criteria.0.banned_licenses", "rules.criteria.0.allowed_licenses", "rules.criteria.0.min_severity"},
-										MaxItems:      1,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"from": {
-													Type:     schema.TypeInt, // Yes, the xray web ui allows floats. The go library says ints. :(
-													Required: true,
-												},
-												"to": {
-													Type:     schema.TypeInt,
-													Required: true,
-												},
-											},
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// policyRulesSchema is shared with the xray_policy_document data source
+func policyRulesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"priority": {
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+
+				"criteria": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							// Security criteria
+							"min_severity": {
+								Type:             schema.TypeString,
+								Optional:         true,
+								ValidateFunc:     validateSeverity,
+								DiffSuppressFunc: suppressCaseInsensitiveDiff,
+							},
+							"cvss_range": {
+								Type:     schema.TypeList,
+								Optional: true,
+								MaxItems: 1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"from": {
+											Type:     schema.TypeInt, // Yes, the xray web ui allows floats. The go library says ints. :(
+											Required: true,
 										},
-									},
-									// License Criteria
-									"allow_unknown": {
-										Type:          schema.TypeBool,
-										Optional:      true,
-										ConflictsWith: []string{"rules.criteria.0.min_severity", "rules.criteria.0.cvss_range"},
-									},
-									"banned_licenses": {
-										Type:          schema.TypeList,
-										Optional:      true,
-										ConflictsWith: []string{"rules.criteria.0.min_severity", "rules.criteria.0.cvss_range"},
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
+										"to": {
+											Type:     schema.TypeInt,
+											Required: true,
 										},
 									},
-									"allowed_licenses": {
-										Type:          schema.TypeList,
-										Optional:      true,
-										ConflictsWith: []string{"rules.criteria.0.min_severity", "rules.criteria.0.cvss_range"},
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
+								},
+							},
+							"vulnerability_ids": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validateVulnerabilityID,
+								},
+							},
+							"package_type": {
+								Type:             schema.TypeString,
+								Optional:         true,
+								ValidateFunc:     validatePackageType,
+								DiffSuppressFunc: suppressCaseInsensitiveDiff,
+							},
+							"package_name": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"package_versions": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validatePackageVersion,
+								},
+							},
+							"fix_version_dependant": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"applicable_cves_only": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							// Exposures and malicious packages need Xray Advanced Security
+							"exposures": {
+								Type:     schema.TypeList,
+								Optional: true,
+								MaxItems: 1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"min_severity": {
+											Type:             schema.TypeString,
+											Optional:         true,
+											Default:          "All",
+											ValidateFunc:     validateExposureSeverity,
+											DiffSuppressFunc: suppressCaseInsensitiveDiff,
+										},
+										"secrets": {
+											Type:     schema.TypeBool,
+											Optional: true,
+											Default:  false,
+										},
+										"applications": {
+											Type:     schema.TypeBool,
+											Optional: true,
+											Default:  false,
+										},
+										"services": {
+											Type:     schema.TypeBool,
+											Optional: true,
+											Default:  false,
+										},
+										"iac": {
+											Type:     schema.TypeBool,
+											Optional: true,
+											Default:  false,
 										},
 									},
 								},
 							},
+							"malicious_package": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							// License Criteria
+							"allow_unknown": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"banned_licenses": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validateLicenseName,
+								},
+							},
+							"allowed_licenses": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validateLicenseName,
+								},
+							},
+							"banned_license_categories": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validation.StringInSlice(validLicenseCategories, false),
+								},
+							},
+							"allowed_license_categories": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validation.StringInSlice(validLicenseCategories, false),
+								},
+							},
+							"multi_license_permissive": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
 						},
-						"actions": {
-							Type:     schema.TypeList,
-							Optional: true,
-							MaxItems: 1,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"mails": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
-										},
-									},
-									"fail_build": {
-										Type:     schema.TypeBool,
-										Optional: true,
-									},
-									"block_download": {
-										Type:     schema.TypeList,
-										Required: true,
-										// TODO: In an ideal world, this would be optional (see note in expandActions)
-										MaxItems: 1,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"unscanned": {
-													Type:     schema.TypeBool,
-													Required: true,
-												},
-												"active": {
-													Type:     schema.TypeBool,
-													Required: true,
-												},
-											},
+					},
+				},
+				"actions": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"mails": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+							"fail_build": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"block_download": {
+								Type:     schema.TypeList,
+								Required: true,
+								// TODO: In an ideal world, this would be optional (see note in expandActions)
+								MaxItems: 1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"unscanned": {
+											Type:     schema.TypeBool,
+											Required: true,
 										},
-									},
-									"webhooks": {
-										Type:     schema.TypeList,
-										Optional: true,
-										Elem: &schema.Schema{
-											Type: schema.TypeString,
+										"active": {
+											Type:     schema.TypeBool,
+											Required: true,
 										},
 									},
-									"custom_severity": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
 								},
 							},
+							"webhooks": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+							"custom_severity": {
+								Type:             schema.TypeString,
+								Optional:         true,
+								ValidateFunc:     validateSeverity,
+								DiffSuppressFunc: suppressCaseInsensitiveDiff,
+							},
+							"notify_watch_recipients": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"notify_deployer": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"create_ticket_enabled": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"build_failure_grace_period_in_days": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								ValidateFunc: validation.IntAtLeast(0),
+							},
+							"block_release_bundle_distribution": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"block_release_bundle_promotion": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"fail_pull_request": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
 						},
 					},
 				},
@@ -175,37 +342,260 @@ func resourceXrayPolicy() *schema.Resource {
 	}
 }
 
-func expandPolicy(d *schema.ResourceData) *v1.Policy {
-	policy := new(v1.Policy)
+// The criteria and actions blocks are nested inside a list of rules, so ConflictsWith can't express which
+// attributes belong together. Instead every rule is checked against the policy type here.
+func resourceXrayPolicyCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("rules") {
+		return nil
+	}
+
+	// A rule with a value that is only known at apply time, e.g. one taken from another resource, would look like
+	// it's missing that value. Such rules are left out (but keep their index) and Xray checks them instead.
+	rules := d.Get("rules").([]interface{})
+	ruleSchema := resourceXrayPolicy().Schema["rules"].Elem.(*schema.Resource).Schema
+	known := true
+	for i := range rules {
+		if !newValuesKnown(d, fmt.Sprintf("rules.%d", i), ruleSchema) {
+			rules[i] = nil
+			known = false
+		}
+	}
+
+	policyType := d.Get("type").(string)
+	if err := validatePolicyRules(policyType, rules); err != nil {
+		return err
+	}
+
+	// The categories are expanded with the catalogue of this provider version, which may differ from the one the
+	// policy was last written with
+	if !known {
+		return d.SetNewComputed("expanded_licenses")
+	}
+	expanded := []interface{}{}
+	if policyType == "license" {
+		expanded = licenseExpansions(rules)
+	}
+	if !reflect.DeepEqual(d.Get("expanded_licenses"), expanded) {
+		return d.SetNew("expanded_licenses", expanded)
+	}
+	return nil
+}
+
+// newValuesKnown reports whether every value under prefix, including those of nested blocks and lists, is known
+func newValuesKnown(d *schema.ResourceDiff, prefix string, s map[string]*schema.Schema) bool {
+	for k, v := range s {
+		key := prefix + "." + k
+		if !d.NewValueKnown(key) {
+			return false
+		}
+		if v.Type != schema.TypeList {
+			continue
+		}
+		l, _ := d.Get(key).([]interface{})
+		for i := range l {
+			item := fmt.Sprintf("%s.%d", key, i)
+			if r, ok := v.Elem.(*schema.Resource); ok {
+				if !newValuesKnown(d, item, r.Schema) {
+					return false
+				}
+			} else if !d.NewValueKnown(item) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validatePolicyRules checks every rule against the policy type. Rules that are nil aren't checked.
+func validatePolicyRules(policyType string, rules []interface{}) error {
+	var errs *multierror.Error
+	priorities := map[int]int{}
+
+	for i, raw := range rules {
+		rule, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		prefix := fmt.Sprintf("rules.%d (%q)", i, rule["name"].(string))
+
+		priority := rule["priority"].(int)
+		if other, ok := priorities[priority]; ok {
+			errs = multierror.Append(errs, fmt.Errorf("%s: priority %d is already used by rules.%d", prefix, priority, other))
+		} else {
+			priorities[priority] = i
+		}
+
+		if actions, ok := rule["actions"].([]interface{}); ok && len(actions) > 0 && actions[0] != nil {
+			errs = multierror.Append(errs, validatePolicyActions(prefix, actions[0].(map[string]interface{})))
+		}
+
+		criteria := rule["criteria"].([]interface{})
+		if len(criteria) == 0 || criteria[0] == nil {
+			continue
+		}
+		m := criteria[0].(map[string]interface{})
+		security := setCriteria(m, securityCriteria...)
+		license := setCriteria(m, licenseCriteria...)
+
+		switch policyType {
+		case "security":
+			for _, k := range license {
+				errs = multierror.Append(errs, fmt.Errorf("%s: criteria.%s cannot be used in a security policy", prefix, k))
+			}
+			errs = multierror.Append(errs, validateSecurityCriteria(prefix, m))
+		case "license":
+			for _, k := range security {
+				errs = multierror.Append(errs, fmt.Errorf("%s: criteria.%s cannot be used in a license policy", prefix, k))
+			}
+			allowed := setCriteria(m, "allowed_licenses", "allowed_license_categories")
+			banned := setCriteria(m, "banned_licenses", "banned_license_categories")
+			if len(allowed) > 0 && len(banned) > 0 {
+				errs = multierror.Append(errs, fmt.Errorf("%s: only one of %s can be set", prefix, joinCriteria([]string{allowed[0], banned[0]})))
+			}
+		default:
+			for _, k := range append(security, license...) {
+				errs = multierror.Append(errs, fmt.Errorf("%s: criteria.%s cannot be used in a %s policy", prefix, k, policyType))
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// securityCriteria lists every security criteria attribute. A rule matches on exactly one of securityMatches
+// (a package counts as package_name), and the remaining attributes refine how that match is made.
+var securityCriteria = []string{
+	"min_severity", "cvss_range", "vulnerability_ids",
+	"package_type", "package_name", "package_versions",
+	"fix_version_dependant", "applicable_cves_only",
+	"exposures", "malicious_package",
+}
+
+var licenseCriteria = []string{
+	"allow_unknown", "banned_licenses", "allowed_licenses",
+	"banned_license_categories", "allowed_license_categories", "multi_license_permissive",
+}
+
+var securityMatches = []string{"min_severity", "cvss_range", "vulnerability_ids", "package_name", "exposures", "malicious_package"}
+
+var exposureCategories = []string{"secrets", "applications", "services", "iac"}
+
+func validateSecurityCriteria(prefix string, m map[string]interface{}) error {
+	var errs *multierror.Error
+
+	matches := setCriteria(m, "min_severity", "cvss_range", "vulnerability_ids", "exposures", "malicious_package")
+	if pkg := setCriteria(m, "package_type", "package_name", "package_versions"); len(pkg) > 0 {
+		matches = append(matches, "package_name")
+		for _, k := range []string{"package_type", "package_name"} {
+			if v, _ := m[k].(string); v == "" {
+				errs = multierror.Append(errs, fmt.Errorf("%s: criteria.%s must be set to match a package", prefix, k))
+			}
+		}
+	}
+	if len(matches) == 0 {
+		errs = multierror.Append(errs, fmt.Errorf("%s: one of %s must be set in a security policy", prefix, joinCriteria(securityMatches)))
+	} else if len(matches) > 1 {
+		errs = multierror.Append(errs, fmt.Errorf("%s: only one of %s can be set", prefix, joinCriteria(matches)))
+	}
+
+	if exposures, ok := m["exposures"].([]interface{}); ok && len(exposures) > 0 && exposures[0] != nil {
+		if len(setCriteria(exposures[0].(map[string]interface{}), exposureCategories...)) == 0 {
+			errs = multierror.Append(errs, fmt.Errorf("%s: criteria.exposures must enable at least one of %s", prefix, strings.Join(exposureCategories, ", ")))
+		}
+	}
+
+	if len(setCriteria(m, "min_severity", "cvss_range")) == 0 {
+		for _, k := range setCriteria(m, "fix_version_dependant", "applicable_cves_only") {
+			errs = multierror.Append(errs, fmt.Errorf("%s: criteria.%s can only be used with criteria.min_severity or criteria.cvss_range", prefix, k))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// joinCriteria formats attribute names for an error message, e.g. "criteria.a, criteria.b or criteria.c"
+func joinCriteria(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = "criteria." + k
+	}
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// validatePolicyActions checks the actions that only take effect together with another action
+func validatePolicyActions(prefix string, m map[string]interface{}) error {
+	var errs *multierror.Error
+
+	if days, ok := m["build_failure_grace_period_in_days"].(int); ok && days > 0 {
+		if failBuild, _ := m["fail_build"].(bool); !failBuild {
+			errs = multierror.Append(errs, fmt.Errorf("%s: actions.build_failure_grace_period_in_days can only be used with actions.fail_build = true", prefix))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// setCriteria returns which of the given criteria attributes have a non-zero value
+func setCriteria(m map[string]interface{}, keys ...string) []string {
+	set := []string{}
+	for _, k := range keys {
+		switch v := m[k].(type) {
+		case string:
+			if v != "" {
+				set = append(set, k)
+			}
+		case bool:
+			if v {
+				set = append(set, k)
+			}
+		case []interface{}:
+			if len(v) > 0 {
+				set = append(set, k)
+			}
+		}
+	}
+	return set
+}
+
+func expandPolicy(d *schema.ResourceData) *xrayPolicy {
+	policy := new(xrayPolicy)
 
 	policy.Name = xray.String(d.Get("name").(string))
 	if v, ok := d.GetOk("type"); ok {
 		policy.Type = xray.String(v.(string))
 	}
-	if v, ok := d.GetOk("description"); ok {
-		policy.Description = xray.String(v.(string))
-	}
+	policy.Description = getOptionalString(d, "description")
+	policy.extraJSON = d.Get("extra_json").(string)
 	if v, ok := d.GetOk("author"); ok {
 		policy.Author = xray.String(v.(string))
 	}
-	policyRules := expandRules(d.Get("rules").([]interface{}))
+	policyRules := expandRules(d)
 	policy.Rules = &policyRules
 
 	return policy
 }
 
-func expandRules(configured []interface{}) []v1.PolicyRule {
-	rules := make([]v1.PolicyRule, 0, len(configured))
+// The rule expanders are passed the path to their block rather than its raw value, so that the getOptional
+// helpers can tell whether each attribute was actually set
+func expandRules(d *schema.ResourceData) []xrayPolicyRule {
+	configured := d.Get("rules").([]interface{})
+	rules := make([]xrayPolicyRule, 0, len(configured))
 
-	for _, raw := range configured {
-		rule := new(v1.PolicyRule)
+	for i, raw := range configured {
+		rule := new(xrayPolicyRule)
 		data := raw.(map[string]interface{})
+		prefix := fmt.Sprintf("rules.%d", i)
 		rule.Name = xray.String(data["name"].(string))
 		rule.Priority = xray.Int(data["priority"].(int))
 
-		rule.Criteria = expandCriteria(data["criteria"].([]interface{}))
-		if v, ok := data["actions"]; ok {
-			rule.Actions = expandActions(v.([]interface{}))
+		if len(data["criteria"].([]interface{})) > 0 {
+			rule.Criteria = expandCriteria(d, prefix+".criteria.0", d.Get("type").(string))
+		}
+		if v, ok := data["actions"]; ok && len(v.([]interface{})) > 0 {
+			rule.Actions = expandActions(d, prefix+".actions.0")
 		}
 		rules = append(rules, *rule)
 	}
@@ -213,39 +603,132 @@ func expandRules(configured []interface{}) []v1.PolicyRule {
 	return rules
 }
 
-func expandCriteria(l []interface{}) *v1.PolicyRuleCriteria {
-	if len(l) == 0 {
-		return nil
+// The API doesn't allow both security and license criteria to be _set_, even if they have empty values,
+// so only the kind of criteria that belongs to the policy type is sent
+func expandCriteria(d *schema.ResourceData, prefix, policyType string) *xrayPolicyRuleCriteria {
+	m := d.Get(prefix).(map[string]interface{}) // We made this a list of one to make schema validation easier
+
+	switch policyType {
+	case "security":
+		return expandSecurityCriteria(d, prefix, m)
+	case "license":
+		return expandLicenseCriteria(d, prefix, m)
+	default:
+		return new(xrayPolicyRuleCriteria)
 	}
+}
 
-	m := l[0].(map[string]interface{}) // We made this a list of one to make schema validation easier
-	criteria := new(v1.PolicyRuleCriteria)
-
-	// The API doesn't allow both severity and license criteria to be _set_, even if they have empty values
-	// So we have to figure out which group is actually empty and not even set it
-	minSev := xray.String(m["min_severity"].(string))
-	cvss := expandCVSSRange(m["cvss_range"].([]interface{}))
-	allowUnk := xray.Bool(m["allow_unknown"].(bool))
-	banned := expandLicenses(m["banned_licenses"].([]interface{}))
-	allowed := expandLicenses(m["allowed_licenses"].([]interface{}))
-
-	if *minSev == "" && cvss == nil {
-		// If these are both the default values, we must be using license criteria
-		criteria.AllowUnkown = allowUnk // "Unkown" is a typo in xray-oss
-		criteria.BannedLicenses = banned
-		criteria.AllowedLicenses = allowed
-	} else {
-		// This is also picky about not allowing empty values to be set
-		if cvss == nil {
-			criteria.MinimumSeverity = minSev
-		} else {
-			criteria.CVSSRange = cvss
-		}
+func expandSecurityCriteria(d *schema.ResourceData, prefix string, m map[string]interface{}) *xrayPolicyRuleCriteria {
+	criteria := new(xrayPolicyRuleCriteria)
+
+	// This is also picky about not allowing empty values to be set
+	if v := m["min_severity"].(string); v != "" {
+		criteria.MinimumSeverity = xray.String(v)
+	}
+	criteria.CVSSRange = expandCVSSRange(m["cvss_range"].([]interface{}))
+	criteria.VulnerabilityIDs = expandStringList(m["vulnerability_ids"].([]interface{}))
+	if v := m["package_type"].(string); v != "" {
+		criteria.PackageType = xray.String(v)
+	}
+	if v := m["package_name"].(string); v != "" {
+		criteria.PackageName = xray.String(v)
+	}
+	criteria.PackageVersions = expandStringList(m["package_versions"].([]interface{}))
+	if l := m["exposures"].([]interface{}); len(l) > 0 && l[0] != nil {
+		criteria.Exposures = expandExposures(l[0].(map[string]interface{}))
+	}
+	if m["malicious_package"].(bool) {
+		criteria.MaliciousPackage = xray.Bool(true)
+	}
+	// These only refine a severity or CVSS match, and Xray rejects them next to anything else
+	if criteria.MinimumSeverity != nil || criteria.CVSSRange != nil {
+		criteria.FixVersionDependant = getOptionalBool(d, prefix+".fix_version_dependant")
+		criteria.ApplicableCVEsOnly = getOptionalBool(d, prefix+".applicable_cves_only")
 	}
 
 	return criteria
 }
 
+func expandLicenseCriteria(d *schema.ResourceData, prefix string, m map[string]interface{}) *xrayPolicyRuleCriteria {
+	criteria := new(xrayPolicyRuleCriteria)
+
+	// Xray treats a missing allow_unknown as false anyway, but sending it keeps the policy as written
+	criteria.AllowUnkown = xray.Bool(m["allow_unknown"].(bool)) // "Unkown" is a typo in xray-oss
+	criteria.MultiLicensePermissive = getOptionalBool(d, prefix+".multi_license_permissive")
+	criteria.BannedLicenses = expandLicenseList(m["banned_licenses"].([]interface{}), m["banned_license_categories"].([]interface{}))
+	criteria.AllowedLicenses = expandLicenseList(m["allowed_licenses"].([]interface{}), m["allowed_license_categories"].([]interface{}))
+
+	return criteria
+}
+
+// expandLicenseList adds every current license in the given categories to the listed licenses, since Xray
+// itself has no notion of license categories
+func expandLicenseList(licenses, categories []interface{}) *[]string {
+	l := expandStringList(licenses)
+	if len(categories) == 0 {
+		return l
+	}
+	if l == nil {
+		l = &[]string{}
+	}
+	*l = append(*l, expandStringSlice(categoryLicenses(licenses, categories))...)
+	return l
+}
+
+// categoryLicenses returns the licenses in the given categories that aren't listed already
+func categoryLicenses(listed, categories []interface{}) []interface{} {
+	skip := map[string]bool{}
+	for _, license := range listed {
+		skip[license.(string)] = true
+	}
+
+	l := []interface{}{}
+	for _, license := range licensesInCategories(expandStringSlice(categories)) {
+		if !skip[license] {
+			l = append(l, license)
+		}
+	}
+	return l
+}
+
+// licenseExpansions lists the licenses that the categories of each rule currently stand for, in the format of the
+// expanded_licenses attribute. Rules without categories are left out.
+func licenseExpansions(rules []interface{}) []interface{} {
+	l := []interface{}{}
+	for _, raw := range rules {
+		rule, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		criteria, _ := rule["criteria"].([]interface{})
+		if len(criteria) == 0 || criteria[0] == nil {
+			continue
+		}
+		m := criteria[0].(map[string]interface{})
+		banned, _ := m["banned_license_categories"].([]interface{})
+		allowed, _ := m["allowed_license_categories"].([]interface{})
+		if len(banned) == 0 && len(allowed) == 0 {
+			continue
+		}
+
+		listedBanned, _ := m["banned_licenses"].([]interface{})
+		listedAllowed, _ := m["allowed_licenses"].([]interface{})
+		l = append(l, map[string]interface{}{
+			"rule":             rule["name"],
+			"banned_licenses":  categoryLicenses(listedBanned, banned),
+			"allowed_licenses": categoryLicenses(listedAllowed, allowed),
+		})
+	}
+	return l
+}
+
+func expandStringSlice(l []interface{}) []string {
+	if s := expandStringList(l); s != nil {
+		return *s
+	}
+	return []string{}
+}
+
 func expandCVSSRange(l []interface{}) *v1.PolicyCVSSRange {
 	if len(l) == 0 {
 		return nil
@@ -253,43 +736,41 @@ func expandCVSSRange(l []interface{}) *v1.PolicyCVSSRange {
 
 	m := l[0].(map[string]interface{})
 	cvssrange := &v1.PolicyCVSSRange{
-		From:   xray.Int(m["from"].(int)),
-		To: xray.Int(m["to"].(int)),
+		From: xray.Int(m["from"].(int)),
+		To:   xray.Int(m["to"].(int)),
 	}
 	return cvssrange
 }
 
-func expandLicenses(l []interface{}) *[]string {
-	if len(l) == 0 {
-		return nil
+// The exposure categories all have defaults, so they are always sent
+func expandExposures(m map[string]interface{}) *xrayPolicyExposures {
+	return &xrayPolicyExposures{
+		MinSeverity:  xray.String(m["min_severity"].(string)),
+		Secrets:      xray.Bool(m["secrets"].(bool)),
+		Applications: xray.Bool(m["applications"].(bool)),
+		Services:     xray.Bool(m["services"].(bool)),
+		IaC:          xray.Bool(m["iac"].(bool)),
 	}
-
-	licenses := make([]string, 0, len(l))
-	for _, license := range l {
-		licenses = append(licenses, license.(string))
-	}
-	return &licenses
 }
 
-func expandActions(l []interface{}) *v1.PolicyRuleActions {
+func expandStringList(l []interface{}) *[]string {
 	if len(l) == 0 {
 		return nil
 	}
-	
-	actions := new(v1.PolicyRuleActions)
-	m := l[0].(map[string]interface{}) // We made this a list of one to make schema validation easier
 
-	if v, ok := m["mails"]; ok && len(v.([]interface{})) > 0 {
-		m := v.([]interface{})
-		mails := make([]string, 0, len(m))
-		for _, mail := range m {
-			mails = append(mails, mail.(string))
-		}
-		actions.Mails = &mails
-	}
-	if v, ok := m["fail_build"]; ok {
-		actions.FailBuild = xray.Bool(v.(bool))
+	list := make([]string, 0, len(l))
+	for _, v := range l {
+		list = append(list, v.(string))
 	}
+	return &list
+}
+
+func expandActions(d *schema.ResourceData, prefix string) *xrayPolicyRuleActions {
+	actions := new(xrayPolicyRuleActions)
+	m := d.Get(prefix).(map[string]interface{}) // We made this a list of one to make schema validation easier
+
+	actions.Mails = getOptionalStringList(d, prefix+".mails")
+	actions.FailBuild = getOptionalBool(d, prefix+".fail_build")
 
 	if v, ok := m["block_download"]; ok {
 		if len(v.([]interface{})) > 0 {
@@ -313,30 +794,28 @@ func expandActions(l []interface{}) *v1.PolicyRuleActions {
 		}
 	}
 
-	if v, ok := m["webhooks"]; ok && len(v.([]interface{})) > 0 {
-		m := v.([]interface{})
-		webhooks := make([]string, 0, len(m))
-		for _, hook := range m {
-			webhooks = append(webhooks, hook.(string))
-		}
-		actions.Webhooks = &webhooks
-	}
-	if v, ok := m["custom_severity"]; ok {
-		actions.CustomSeverity = xray.String(v.(string))
-	}
+	actions.Webhooks = getOptionalStringList(d, prefix+".webhooks")
+	actions.CustomSeverity = getOptionalString(d, prefix+".custom_severity")
+	actions.NotifyWatchRecipients = getOptionalBool(d, prefix+".notify_watch_recipients")
+	actions.NotifyDeployer = getOptionalBool(d, prefix+".notify_deployer")
+	actions.CreateTicketEnabled = getOptionalBool(d, prefix+".create_ticket_enabled")
+	actions.BuildFailureGracePeriodInDays = getOptionalInt(d, prefix+".build_failure_grace_period_in_days")
+	actions.BlockReleaseBundleDistribution = getOptionalBool(d, prefix+".block_release_bundle_distribution")
+	actions.BlockReleaseBundlePromotion = getOptionalBool(d, prefix+".block_release_bundle_promotion")
+	actions.FailPullRequest = getOptionalBool(d, prefix+".fail_pull_request")
 
 	return actions
 }
 
-func flattenRules(rules []v1.PolicyRule) []interface{} {
+func flattenRules(rules []xrayPolicyRule) []interface{} {
 	l := make([]interface{}, len(rules))
 
 	for i, rule := range rules {
 		m := map[string]interface{}{
-			"name": *rule.Name,
+			"name":     *rule.Name,
 			"priority": *rule.Priority,
 			"criteria": flattenCriteria(rule.Criteria),
-			"actions": flattenActions(rule.Actions),
+			"actions":  flattenActions(rule.Actions),
 		}
 		l[i] = m
 	}
@@ -344,7 +823,7 @@ func flattenRules(rules []v1.PolicyRule) []interface{} {
 	return l
 }
 
-func flattenCriteria(criteria *v1.PolicyRuleCriteria) []interface{} {
+func flattenCriteria(criteria *xrayPolicyRuleCriteria) []interface{} {
 	if criteria == nil {
 		return []interface{}{}
 	}
@@ -356,6 +835,33 @@ func flattenCriteria(criteria *v1.PolicyRuleCriteria) []interface{} {
 	if criteria.MinimumSeverity != nil {
 		m["min_severity"] = *criteria.MinimumSeverity
 	}
+	if criteria.VulnerabilityIDs != nil {
+		m["vulnerability_ids"] = *criteria.VulnerabilityIDs
+	}
+	if criteria.PackageType != nil {
+		m["package_type"] = *criteria.PackageType
+	}
+	if criteria.PackageName != nil {
+		m["package_name"] = *criteria.PackageName
+	}
+	if criteria.PackageVersions != nil {
+		m["package_versions"] = *criteria.PackageVersions
+	}
+	if criteria.FixVersionDependant != nil {
+		m["fix_version_dependant"] = *criteria.FixVersionDependant
+	}
+	if criteria.ApplicableCVEsOnly != nil {
+		m["applicable_cves_only"] = *criteria.ApplicableCVEsOnly
+	}
+	if criteria.Exposures != nil {
+		m["exposures"] = flattenExposures(criteria.Exposures)
+	}
+	if criteria.MaliciousPackage != nil {
+		m["malicious_package"] = *criteria.MaliciousPackage
+	}
+	if criteria.MultiLicensePermissive != nil {
+		m["multi_license_permissive"] = *criteria.MultiLicensePermissive
+	}
 	if criteria.AllowUnkown != nil {
 		m["allow_unknown"] = *criteria.AllowUnkown // Same typo in the library
 	}
@@ -373,15 +879,91 @@ func flattenCVSSRange(cvss *v1.PolicyCVSSRange) []interface{} {
 	if cvss == nil {
 		return []interface{}{}
 	}
-	
+
 	m := map[string]interface{}{
 		"from": *cvss.From,
-		"to": *cvss.To,
+		"to":   *cvss.To,
+	}
+	return []interface{}{m}
+}
+
+// restoreLicenseCategories puts the configured license categories back into rules flattened from Xray, and moves
+// every license that isn't listed explicitly out of the license lists. It returns the licenses it moved, in the
+// format of the expanded_licenses attribute, so that any difference from what the categories stand for shows up
+// in the plan. Rules are matched up by position.
+func restoreLicenseCategories(rules, configured []interface{}) []interface{} {
+	expanded := []interface{}{}
+	for i := 0; i < len(rules) && i < len(configured); i++ {
+		rule := rules[i].(map[string]interface{})
+		criteria := rule["criteria"].([]interface{})
+		configuredCriteria, _ := configured[i].(map[string]interface{})["criteria"].([]interface{})
+		if len(criteria) == 0 || len(configuredCriteria) == 0 || configuredCriteria[0] == nil {
+			continue
+		}
+		m := criteria[0].(map[string]interface{})
+		conf := configuredCriteria[0].(map[string]interface{})
+
+		banned, _ := conf["banned_license_categories"].([]interface{})
+		allowed, _ := conf["allowed_license_categories"].([]interface{})
+		if len(banned) == 0 && len(allowed) == 0 {
+			continue
+		}
+
+		expansion := map[string]interface{}{"rule": rule["name"]}
+		for _, key := range []string{"banned_licenses", "allowed_licenses"} {
+			categoriesKey := strings.TrimSuffix(key, "s") + "_categories"
+			expansion[key] = []interface{}{}
+			categories, _ := conf[categoriesKey].([]interface{})
+			if len(categories) == 0 {
+				continue
+			}
+			m[categoriesKey] = categories
+
+			explicit := map[string]bool{}
+			listed, _ := conf[key].([]interface{})
+			for _, license := range listed {
+				explicit[license.(string)] = true
+			}
+
+			read, _ := m[key].([]string)
+			licenses, others := []string{}, []string{}
+			for _, license := range read {
+				if explicit[license] {
+					licenses = append(licenses, license)
+				} else {
+					others = append(others, license)
+				}
+			}
+			sort.Strings(others)
+			m[key] = licenses
+			expansion[key] = others
+		}
+		expanded = append(expanded, expansion)
+	}
+	return expanded
+}
+
+func flattenExposures(exposures *xrayPolicyExposures) []interface{} {
+	m := map[string]interface{}{}
+	if exposures.MinSeverity != nil {
+		m["min_severity"] = *exposures.MinSeverity
+	}
+	if exposures.Secrets != nil {
+		m["secrets"] = *exposures.Secrets
+	}
+	if exposures.Applications != nil {
+		m["applications"] = *exposures.Applications
+	}
+	if exposures.Services != nil {
+		m["services"] = *exposures.Services
+	}
+	if exposures.IaC != nil {
+		m["iac"] = *exposures.IaC
 	}
 	return []interface{}{m}
 }
 
-func flattenActions(actions *v1.PolicyRuleActions) []interface{} {
+func flattenActions(actions *xrayPolicyRuleActions) []interface{} {
 	if actions == nil {
 		return []interface{}{}
 	}
@@ -402,6 +984,27 @@ func flattenActions(actions *v1.PolicyRuleActions) []interface{} {
 	if actions.CustomSeverity != nil {
 		m["custom_severity"] = *actions.CustomSeverity
 	}
+	if actions.NotifyWatchRecipients != nil {
+		m["notify_watch_recipients"] = *actions.NotifyWatchRecipients
+	}
+	if actions.NotifyDeployer != nil {
+		m["notify_deployer"] = *actions.NotifyDeployer
+	}
+	if actions.CreateTicketEnabled != nil {
+		m["create_ticket_enabled"] = *actions.CreateTicketEnabled
+	}
+	if actions.BuildFailureGracePeriodInDays != nil {
+		m["build_failure_grace_period_in_days"] = *actions.BuildFailureGracePeriodInDays
+	}
+	if actions.BlockReleaseBundleDistribution != nil {
+		m["block_release_bundle_distribution"] = *actions.BlockReleaseBundleDistribution
+	}
+	if actions.BlockReleaseBundlePromotion != nil {
+		m["block_release_bundle_promotion"] = *actions.BlockReleaseBundlePromotion
+	}
+	if actions.FailPullRequest != nil {
+		m["fail_pull_request"] = *actions.FailPullRequest
+	}
 
 	return []interface{}{m}
 }
@@ -422,11 +1025,24 @@ func flattenBlockDownload(bd *v1.BlockDownloadSettings) []interface{} {
 	return []interface{}{m}
 }
 
+// policyNotFound reports whether a policy lookup failed because the policy doesn't exist. Depending on the
+// version, Xray answers with either a 404 or a 500 naming the missing policy.
+func policyNotFound(resp *http.Response, err error, name string) bool {
+	if resp == nil {
+		return false
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return true
+	}
+	return resp.StatusCode == http.StatusInternalServerError && err != nil &&
+		err.Error() == fmt.Sprintf("{\"error\":\"Failed to find Policy %s\"}", name)
+}
+
 func resourceXrayPolicyCreate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*xray.Xray)
+	c := meta.(*xrayClient)
 
 	policy := expandPolicy(d)
-	resp, err := c.V1.Policies.CreatePolicy(context.Background(), policy)
+	resp, err := c.createPolicy(context.Background(), policy)
 	if err != nil {
 		return err
 	}
@@ -439,9 +1055,9 @@ func resourceXrayPolicyCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceXrayPolicyRead(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*xray.Xray)
+	c := meta.(*xrayClient)
 
-	policy, resp, err := c.V1.Policies.GetPolicy(context.Background(), d.Id())
+	policy, resp, err := c.getPolicy(context.Background(), d.Id())
 	if resp.StatusCode == http.StatusNotFound {
 		log.Printf("[WARN] Xray policy (%s) not found, removing from state", d.Id())
 		d.SetId("")
@@ -465,18 +1081,36 @@ func resourceXrayPolicyRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("modified", *policy.Modified); err != nil {
 		return err
 	}
-	if err := d.Set("rules", flattenRules(*policy.Rules)); err != nil {
+	rules := flattenRules(*policy.Rules)
+	expanded := restoreLicenseCategories(rules, d.Get("rules").([]interface{}))
+	if err := d.Set("rules", rules); err != nil {
+		return err
+	}
+	if err := d.Set("expanded_licenses", expanded); err != nil {
+		return err
+	}
+	extra, err := flattenExtraJSON(policy.response, d.Get("extra_json").(string), true)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("extra_json", extra); err != nil {
 		return err
 	}
 	return nil
 }
 
 func resourceXrayPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*xray.Xray)
+	c := meta.(*xrayClient)
 
 	policy := expandPolicy(d)
-	_, err := c.V1.Policies.UpdatePolicy(context.Background(), d.Id(), policy)
-	if err != nil {
+	if d.HasChange("name") {
+		// Don't record any of the new values unless the whole rename goes through
+		d.Partial(true)
+		if err := renamePolicy(c, d.Id(), policy); err != nil {
+			return err
+		}
+		d.Partial(false)
+	} else if _, err := c.updatePolicy(context.Background(), d.Id(), policy); err != nil {
 		return err
 	}
 
@@ -484,8 +1118,71 @@ func resourceXrayPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
 	return resourceXrayPolicyRead(d, meta)
 }
 
+// renamePolicy moves a policy to a new name. The v1 API identifies policies by name and can't rename them, so the
+// policy is created under its new name, every watch is pointed at it, and the old policy is deleted.
+// If any step fails, everything done so far is undone.
+func renamePolicy(c *xrayClient, oldName string, policy *xrayPolicy) error {
+	newName := *policy.Name
+
+	watches, err := findWatchesWithPolicy(c, oldName)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.createPolicy(context.Background(), policy)
+	if err != nil {
+		return fmt.Errorf("failed to create policy %q to replace %q: %s", newName, oldName, err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("Unexpected status code when creating resource: %d", resp.StatusCode)
+	}
+
+	repointed := []string{}
+	rollback := func(cause error) error {
+		for _, watch := range repointed {
+			if err := replaceWatchPolicy(c, watch, newName, oldName); err != nil {
+				log.Printf("[WARN] Failed to roll back watch (%s) to policy (%s): %s", watch, oldName, err)
+			}
+		}
+		if _, err := c.V1.Policies.DeletePolicy(context.Background(), newName); err != nil {
+			log.Printf("[WARN] Failed to roll back the creation of Xray policy (%s): %s", newName, err)
+		}
+		return cause
+	}
+
+	for _, watch := range watches {
+		if err := replaceWatchPolicy(c, watch, oldName, newName); err != nil {
+			return rollback(err)
+		}
+		repointed = append(repointed, watch)
+	}
+
+	if resp, err := c.V1.Policies.DeletePolicy(context.Background(), oldName); err != nil && !policyNotFound(resp, err, oldName) {
+		return rollback(fmt.Errorf("failed to delete policy %q after renaming it to %q: %s", oldName, newName, err))
+	}
+
+	return nil
+}
+
 func resourceXrayPolicyDelete(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*xray.Xray)
+	c := meta.(*xrayClient)
+
+	// Xray refuses to delete a policy that is still assigned to a watch
+	watches, err := findWatchesWithPolicy(c, d.Id())
+	if err != nil {
+		return err
+	}
+	if len(watches) > 0 {
+		if !d.Get("force_detach").(bool) {
+			return fmt.Errorf("policy %q is still assigned to watches %s. Remove the assignments or set force_detach = true", d.Id(), strings.Join(watches, ", "))
+		}
+		for _, watch := range watches {
+			log.Printf("[INFO] Detaching Xray policy (%s) from watch (%s)", d.Id(), watch)
+			if err := replaceWatchPolicy(c, watch, d.Id(), ""); err != nil {
+				return err
+			}
+		}
+	}
 
 	resp, err := c.V1.Policies.DeletePolicy(context.Background(), d.Id())
 	if resp.StatusCode == http.StatusNotFound {
@@ -2,13 +2,19 @@ package jfrogxray
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/xero-oss/go-xray/xray"
+	v2 "github.com/xero-oss/go-xray/xray/v2"
 )
 
 func TestAccPolicy_basic(t *testing.T) {
@@ -83,6 +89,245 @@ func TestAccPolicy_cvssRange(t *testing.T) {
 	})
 }
 
+func TestAccPolicy_packageCriteria(t *testing.T) {
+	policyName := "terraform-test-policy"
+	resourceName := "xray_policy.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckPolicyDestroy,
+		Providers:    testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccXrayPolicy_packageCriteria(policyName, "(,2.17.0)"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.vulnerability_ids.0", "CVE-2021-44228"),
+					resource.TestCheckResourceAttr(resourceName, "rules.1.criteria.0.package_type", "maven"),
+					resource.TestCheckResourceAttr(resourceName, "rules.1.criteria.0.package_name", "org.apache.logging.log4j:log4j-core"),
+					resource.TestCheckResourceAttr(resourceName, "rules.1.criteria.0.package_versions.0", "(,2.17.0)"),
+					resource.TestCheckResourceAttr(resourceName, "rules.2.criteria.0.fix_version_dependant", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.2.criteria.0.applicable_cves_only", "false"),
+				),
+			},
+			{
+				Config: testAccXrayPolicy_packageCriteria(policyName, "(,2.17.1)"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.1.criteria.0.package_versions.0", "(,2.17.1)"),
+				),
+			},
+		},
+	})
+}
+
+// Exposures and malicious packages need Xray Advanced Security, so this runs against the stand-in server
+func TestAccPolicy_exposures(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_policy.test"
+
+	exposures := func() map[string]interface{} {
+		rules := server.policy("terraform-test-exposures")["rules"].([]interface{})
+		return rules[0].(map[string]interface{})["criteria"].(map[string]interface{})["exposures"].(map[string]interface{})
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayPolicy_exposures("terraform-test-exposures", "High", false)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.exposures.0.min_severity", "High"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.exposures.0.secrets", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.exposures.0.iac", "false"),
+					resource.TestCheckResourceAttr(resourceName, "rules.1.criteria.0.malicious_package", "true"),
+					func(*terraform.State) error {
+						if e := exposures(); e["secrets"] != true || e["iac"] != false {
+							return fmt.Errorf("unexpected exposures sent to Xray: %v", e)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: server.config(testAccXrayPolicy_exposures("terraform-test-exposures", "All", true)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.exposures.0.min_severity", "All"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.exposures.0.iac", "true"),
+					func(*terraform.State) error {
+						if e := exposures(); e["iac"] != true || e["min_severity"] != "All" {
+							return fmt.Errorf("unexpected exposures sent to Xray: %v", e)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      server.config(testAccXrayPolicy_exposuresInvalid("terraform-test-exposures")),
+				ExpectError: regexp.MustCompile(`criteria.exposures must enable at least one of`),
+			},
+		},
+	})
+}
+
+// A criteria value taken from another resource is unknown at plan time, which mustn't look like a missing value
+func TestAccPolicy_unknownCriteria(t *testing.T) {
+	server := newTestXrayServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+resource "xray_api_object" "severity" {
+	path         = "/api/v1/test/objects"
+	id_attribute = "info.id"
+	body         = jsonencode({ severity = "High" })
+}
+
+resource "xray_policy" "test" {
+	name = "terraform-test-unknown-criteria"
+	type = "security"
+
+	rules {
+		name     = "severity"
+		priority = 1
+		criteria {
+			min_severity = jsondecode(xray_api_object.severity.response).severity
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active    = true
+			}
+		}
+	}
+}
+`),
+				Check: resource.TestCheckResourceAttr("xray_policy.test", "rules.0.criteria.0.min_severity", "High"),
+			},
+		},
+	})
+}
+
+// License categories are expanded by the provider, so the stand-in server is enough to check the round trip
+func TestAccPolicy_licenseCategories(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_policy.test"
+
+	bannedOnServer := func(expected ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			rules := server.policy("terraform-test-categories")["rules"].([]interface{})
+			criteria := rules[0].(map[string]interface{})["criteria"].(map[string]interface{})
+			banned := map[string]bool{}
+			for _, l := range criteria["banned_licenses"].([]interface{}) {
+				banned[l.(string)] = true
+			}
+			for _, l := range expected {
+				if !banned[l] {
+					return fmt.Errorf("expected %s to be banned on the server, got %v", l, criteria["banned_licenses"])
+				}
+			}
+			if criteria["allow_unknown"] != false {
+				return fmt.Errorf("expected allow_unknown to be sent as false, got %v", criteria["allow_unknown"])
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayPolicy_licenseCategories("terraform-test-categories", `"copyleft"`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.banned_licenses.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.banned_licenses.0", "MIT"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.banned_license_categories.0", "copyleft"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.multi_license_permissive", "true"),
+					resource.TestCheckResourceAttr(resourceName, "expanded_licenses.0.rule", "no-copyleft"),
+					resource.TestCheckResourceAttr(resourceName, "expanded_licenses.0.banned_licenses.#", fmt.Sprint(len(licensesInCategories([]string{"copyleft"})))),
+					bannedOnServer("MIT", "GPL-3.0-only"),
+				),
+			},
+			{
+				// A license the category stands for going missing in Xray is drift like any other
+				PreConfig: server.edit(func() {
+					rules := server.policies["terraform-test-categories"]["rules"].([]interface{})
+					criteria := rules[0].(map[string]interface{})["criteria"].(map[string]interface{})
+					banned := []interface{}{}
+					for _, l := range criteria["banned_licenses"].([]interface{}) {
+						if l != "GPL-3.0-only" {
+							banned = append(banned, l)
+						}
+					}
+					criteria["banned_licenses"] = banned
+				}),
+				Config:             server.config(testAccXrayPolicy_licenseCategories("terraform-test-categories", `"copyleft"`)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: server.config(testAccXrayPolicy_licenseCategories("terraform-test-categories", `"copyleft"`)),
+				Check:  bannedOnServer("MIT", "GPL-3.0-only"),
+			},
+			{
+				Config: server.config(testAccXrayPolicy_licenseCategories("terraform-test-categories", `"copyleft", "weak_copyleft"`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.banned_licenses.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.banned_license_categories.#", "2"),
+					bannedOnServer("MIT", "GPL-3.0-only", "LGPL-3.0-only"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicy_extraJSON(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_policy.test"
+	extra := `{"rules": [{"actions": {"future_action": true}}], "future_setting": "a"}`
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayPolicy_extraJSON("terraform-test-extra", extra)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "extra_json", `{"future_setting":"a","rules":[{"actions":{"future_action":true}}]}`),
+					func(*terraform.State) error {
+						policy := server.policy("terraform-test-extra")
+						actions := policy["rules"].([]interface{})[0].(map[string]interface{})["actions"].(map[string]interface{})
+						if policy["future_setting"] != "a" || actions["future_action"] != true || actions["block_download"] == nil {
+							return fmt.Errorf("expected the extra JSON to be merged into the policy, got %v", policy)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: server.edit(func() {
+					server.policies["terraform-test-extra"]["future_setting"] = "b"
+				}),
+				Config:             server.config(testAccXrayPolicy_extraJSON("terraform-test-extra", extra)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: server.config(testAccXrayPolicy_extraJSON("terraform-test-extra", extra)),
+				Check: func(*terraform.State) error {
+					if v := server.policy("terraform-test-extra")["future_setting"]; v != "a" {
+						return fmt.Errorf("expected the drift to be corrected, got %v", v)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
 func TestAccPolicy_allActions(t *testing.T) {
 	policyName := "terraform-test-policy"
 	policyDesc := "policy created by xray acceptance tests"
@@ -108,6 +353,11 @@ func TestAccPolicy_allActions(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "rules.0.criteria.0.min_severity", "High"),
 					resource.TestCheckResourceAttr(resourceName, "rules.0.actions.0.fail_build", "true"),
 					resource.TestCheckResourceAttr(resourceName, "rules.0.actions.0.mails.0", actionMail),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.actions.0.notify_deployer", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.actions.0.create_ticket_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.actions.0.build_failure_grace_period_in_days", "5"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.actions.0.block_release_bundle_promotion", "false"),
+					resource.TestCheckResourceAttr(resourceName, "rules.0.actions.0.fail_pull_request", "true"),
 				),
 			},
 			{
@@ -175,6 +425,103 @@ func TestAccPolicy_licenseCriteria(t *testing.T) {
 	})
 }
 
+func TestAccPolicy_forceDetach(t *testing.T) {
+	policyName := "terraform-test-policy"
+	watchName := "terraform-test-external-watch"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckPolicyDestroy(s); err != nil {
+				return err
+			}
+			return testAccCheckWatchDetached(watchName, policyName)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccXrayPolicy_forceDetach(policyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("xray_policy.test", "force_detach", "true"),
+					// A watch Terraform doesn't know about, so it can't order the destroy around it
+					testAccCreateExternalWatch(watchName, policyName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPolicy_rename(t *testing.T) {
+	server := newTestXrayServer(t)
+	policyName := "terraform-test-policy"
+	renamedPolicyName := "terraform-test-policy-renamed"
+	watchName := "terraform-test-watch"
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayPolicy_withWatch(policyName, watchName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("xray_policy.test", "id", policyName),
+					resource.TestCheckResourceAttr("xray_watch.test", "assigned_policies.0.name", policyName),
+				),
+			},
+			{
+				Config: server.config(testAccXrayPolicy_withWatch(renamedPolicyName, watchName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("xray_policy.test", "id", renamedPolicyName),
+					resource.TestCheckResourceAttr("xray_watch.test", "assigned_policies.0.name", renamedPolicyName),
+					func(*terraform.State) error {
+						if server.policy(policyName) != nil {
+							return fmt.Errorf("error: Policy %s still exists after being renamed", policyName)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: server.config(testAccXrayWatch_unassigned(renamedPolicyName)),
+			},
+		},
+	})
+}
+
+func testAccCreateExternalWatch(watchName, policyName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*xrayClient)
+
+		watch := &v2.Watch{
+			GeneralData: &v2.WatchGeneralData{Name: xray.String(watchName), Active: xray.Bool(true)},
+			ProjectResources: &v2.WatchProjectResources{
+				Resources: &[]v2.WatchProjectResource{{Type: xray.String("all-repos"), Name: xray.String("All Repositories")}},
+			},
+			AssignedPolicies: &[]v2.WatchAssignedPolicy{{Name: xray.String(policyName), Type: xray.String("security")}},
+		}
+		_, err := conn.V2.Watches.CreateWatch(context.Background(), watch)
+		return err
+	}
+}
+
+func testAccCheckWatchDetached(watchName, policyName string) error {
+	conn := testAccProvider.Meta().(*xrayClient)
+	defer conn.V2.Watches.DeleteWatch(context.Background(), watchName)
+
+	watch, _, err := conn.V2.Watches.GetWatch(context.Background(), watchName)
+	if err != nil {
+		return fmt.Errorf("error: external watch %s should still exist: %s", watchName, err)
+	}
+	if watch.AssignedPolicies != nil {
+		for _, p := range *watch.AssignedPolicies {
+			if *p.Name == policyName {
+				return fmt.Errorf("error: Policy %s is still assigned to watch %s", policyName, watchName)
+			}
+		}
+	}
+	return nil
+}
+
 // This should be uncommented when someone figures out how to deal with this (see comment in expandActions in the provider)
 /*func TestAccPolicy_missingBlockDownloads(t *testing.T) {
 	policyName   := "terraform-test-policy"
@@ -205,8 +552,381 @@ func TestAccPolicy_licenseCriteria(t *testing.T) {
 	})
 }*/
 
+func testPolicyRule(name string, priority int, criteria map[string]interface{}) map[string]interface{} {
+	m := map[string]interface{}{
+		"min_severity":               "",
+		"cvss_range":                 []interface{}{},
+		"vulnerability_ids":          []interface{}{},
+		"package_type":               "",
+		"package_name":               "",
+		"package_versions":           []interface{}{},
+		"fix_version_dependant":      false,
+		"applicable_cves_only":       false,
+		"exposures":                  []interface{}{},
+		"malicious_package":          false,
+		"allow_unknown":              false,
+		"banned_licenses":            []interface{}{},
+		"allowed_licenses":           []interface{}{},
+		"banned_license_categories":  []interface{}{},
+		"allowed_license_categories": []interface{}{},
+		"multi_license_permissive":   false,
+	}
+	for k, v := range criteria {
+		m[k] = v
+	}
+	return map[string]interface{}{
+		"name":     name,
+		"priority": priority,
+		"criteria": []interface{}{m},
+	}
+}
+
+func testExposures(categories map[string]interface{}) []interface{} {
+	m := map[string]interface{}{"min_severity": "All", "secrets": false, "applications": false, "services": false, "iac": false}
+	for k, v := range categories {
+		m[k] = v
+	}
+	return []interface{}{m}
+}
+
+func testPolicyRuleActions(rule map[string]interface{}, actions map[string]interface{}) map[string]interface{} {
+	rule["actions"] = []interface{}{actions}
+	return rule
+}
+
+func TestValidatePolicyRules(t *testing.T) {
+	cvss := []interface{}{map[string]interface{}{"from": 1, "to": 4}}
+	licenses := []interface{}{"MIT"}
+
+	cases := []struct {
+		name       string
+		policyType string
+		rules      []interface{}
+		errors     []string
+	}{
+		{
+			name:       "valid security rules",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("high", 1, map[string]interface{}{"min_severity": "High"}),
+				testPolicyRule("cvss", 2, map[string]interface{}{"cvss_range": cvss}),
+			},
+		},
+		{
+			name:       "valid license rule",
+			policyType: "license",
+			rules: []interface{}{
+				testPolicyRule("banned", 1, map[string]interface{}{"allow_unknown": true, "banned_licenses": licenses}),
+			},
+		},
+		{
+			name:       "license criteria in a security policy",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"min_severity": "High"}),
+				testPolicyRule("second", 2, map[string]interface{}{"min_severity": "High", "banned_licenses": licenses}),
+			},
+			errors: []string{`rules.1 ("second"): criteria.banned_licenses cannot be used in a security policy`},
+		},
+		{
+			name:       "security criteria in a license policy",
+			policyType: "license",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"min_severity": "High", "allowed_licenses": licenses}),
+			},
+			errors: []string{`rules.0 ("first"): criteria.min_severity cannot be used in a license policy`},
+		},
+		{
+			name:       "both severity and cvss range",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"min_severity": "High", "cvss_range": cvss}),
+			},
+			errors: []string{`rules.0 ("first"): only one of criteria.min_severity or criteria.cvss_range can be set`},
+		},
+		{
+			name:       "allowed and banned licenses",
+			policyType: "license",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"allowed_licenses": licenses, "banned_licenses": licenses}),
+			},
+			errors: []string{`rules.0 ("first"): only one of criteria.allowed_licenses or criteria.banned_licenses can be set`},
+		},
+		{
+			name:       "valid license category rule",
+			policyType: "license",
+			rules: []interface{}{
+				testPolicyRule("copyleft", 1, map[string]interface{}{"banned_license_categories": []interface{}{"copyleft"}, "banned_licenses": licenses, "multi_license_permissive": true}),
+			},
+		},
+		{
+			name:       "allowed licenses and banned categories",
+			policyType: "license",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"allowed_licenses": licenses, "banned_license_categories": []interface{}{"copyleft"}}),
+			},
+			errors: []string{`rules.0 ("first"): only one of criteria.allowed_licenses or criteria.banned_license_categories can be set`},
+		},
+		{
+			name:       "license categories in a security policy",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"min_severity": "High", "allowed_license_categories": []interface{}{"permissive"}}),
+			},
+			errors: []string{`rules.0 ("first"): criteria.allowed_license_categories cannot be used in a security policy`},
+		},
+		{
+			name:       "duplicate priorities",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"min_severity": "High"}),
+				testPolicyRule("second", 2, map[string]interface{}{"min_severity": "Low"}),
+				testPolicyRule("third", 1, map[string]interface{}{"min_severity": "Low"}),
+			},
+			errors: []string{`rules.2 ("third"): priority 1 is already used by rules.0`},
+		},
+		{
+			name:       "valid vulnerability and package rules",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("log4shell", 1, map[string]interface{}{"vulnerability_ids": []interface{}{"CVE-2021-44228"}}),
+				testPolicyRule("log4j", 2, map[string]interface{}{"package_type": "maven", "package_name": "org.apache.logging.log4j:log4j-core", "package_versions": []interface{}{"(,2.17.0)"}}),
+				testPolicyRule("fixable", 3, map[string]interface{}{"min_severity": "High", "fix_version_dependant": true, "applicable_cves_only": true}),
+			},
+		},
+		{
+			name:       "severity and vulnerability ids",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"min_severity": "High", "vulnerability_ids": []interface{}{"CVE-2021-44228"}}),
+			},
+			errors: []string{`rules.0 ("first"): only one of criteria.min_severity or criteria.vulnerability_ids can be set`},
+		},
+		{
+			name:       "package versions without a package",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"package_versions": []interface{}{"(,2.17.0)"}}),
+			},
+			errors: []string{
+				`rules.0 ("first"): criteria.package_type must be set to match a package`,
+				`rules.0 ("first"): criteria.package_name must be set to match a package`,
+			},
+		},
+		{
+			name:       "fix version dependant without a severity",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"vulnerability_ids": []interface{}{"XRAY-1234"}, "fix_version_dependant": true}),
+			},
+			errors: []string{`rules.0 ("first"): criteria.fix_version_dependant can only be used with criteria.min_severity or criteria.cvss_range`},
+		},
+		{
+			name:       "package criteria in a license policy",
+			policyType: "license",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"package_name": "log4j-core", "banned_licenses": licenses}),
+			},
+			errors: []string{`rules.0 ("first"): criteria.package_name cannot be used in a license policy`},
+		},
+		{
+			name:       "valid exposures and malicious package rules",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("secrets", 1, map[string]interface{}{"exposures": testExposures(map[string]interface{}{"secrets": true})}),
+				testPolicyRule("malicious", 2, map[string]interface{}{"malicious_package": true}),
+			},
+		},
+		{
+			name:       "exposures without a category",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"exposures": testExposures(map[string]interface{}{})}),
+			},
+			errors: []string{`rules.0 ("first"): criteria.exposures must enable at least one of secrets, applications, services, iac`},
+		},
+		{
+			name:       "exposures and malicious package",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{"exposures": testExposures(map[string]interface{}{"iac": true}), "malicious_package": true}),
+			},
+			errors: []string{`rules.0 ("first"): only one of criteria.exposures or criteria.malicious_package can be set`},
+		},
+		{
+			name:       "no security criteria",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRule("first", 1, map[string]interface{}{}),
+			},
+			errors: []string{`rules.0 ("first"): one of criteria.min_severity, criteria.cvss_range, criteria.vulnerability_ids, criteria.package_name, criteria.exposures or criteria.malicious_package must be set in a security policy`},
+		},
+		{
+			name:       "grace period with fail_build",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRuleActions(testPolicyRule("first", 1, map[string]interface{}{"min_severity": "High"}),
+					map[string]interface{}{"fail_build": true, "build_failure_grace_period_in_days": 3}),
+			},
+		},
+		{
+			name:       "grace period without fail_build",
+			policyType: "security",
+			rules: []interface{}{
+				testPolicyRuleActions(testPolicyRule("first", 1, map[string]interface{}{"min_severity": "High"}),
+					map[string]interface{}{"fail_build": false, "build_failure_grace_period_in_days": 3}),
+			},
+			errors: []string{`rules.0 ("first"): actions.build_failure_grace_period_in_days can only be used with actions.fail_build = true`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePolicyRules(tc.policyType, tc.rules)
+			if len(tc.errors) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %v, got none", tc.errors)
+			}
+			for _, expected := range tc.errors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to contain %q, got %s", expected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestExpandPolicy_zeroValues(t *testing.T) {
+	rule := func(actions map[string]interface{}) map[string]interface{} {
+		actions["block_download"] = []interface{}{map[string]interface{}{"unscanned": false, "active": false}}
+		return map[string]interface{}{
+			"name":     "test-rule",
+			"priority": 1,
+			"criteria": []interface{}{map[string]interface{}{"min_severity": "High"}},
+			"actions":  []interface{}{actions},
+		}
+	}
+	config := func(actions map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":  "test-policy",
+			"type":  "security",
+			"rules": []interface{}{rule(actions)},
+		}
+	}
+
+	policy := expandPolicy(schema.TestResourceDataRaw(t, resourceXrayPolicy().Schema, config(map[string]interface{}{})))
+	actions := (*policy.Rules)[0].Actions
+	if actions.FailBuild != nil || actions.Mails != nil || actions.CustomSeverity != nil || policy.Description != nil {
+		t.Errorf("expected unset attributes to be left out, got %+v", actions)
+	}
+
+	policy = expandPolicy(schema.TestResourceDataRaw(t, resourceXrayPolicy().Schema, config(map[string]interface{}{"fail_build": false})))
+	actions = (*policy.Rules)[0].Actions
+	if actions.FailBuild == nil || *actions.FailBuild {
+		t.Errorf("expected fail_build to be sent as false, got %v", actions.FailBuild)
+	}
+}
+
+func TestExpandPolicy_extendedActions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceXrayPolicy().Schema, map[string]interface{}{
+		"name": "test-policy",
+		"type": "security",
+		"rules": []interface{}{map[string]interface{}{
+			"name":     "test-rule",
+			"priority": 1,
+			"criteria": []interface{}{map[string]interface{}{"min_severity": "High"}},
+			"actions": []interface{}{map[string]interface{}{
+				"block_download":                     []interface{}{map[string]interface{}{"unscanned": false, "active": false}},
+				"fail_build":                         true,
+				"build_failure_grace_period_in_days": 7,
+				"notify_deployer":                    false,
+				"fail_pull_request":                  true,
+			}},
+		}},
+	})
+
+	body, err := json.Marshal(expandPolicy(d))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`"fail_build":true`,
+		`"build_failure_grace_period_in_days":7`,
+		`"notify_deployer":false`,
+		`"fail_pull_request":true`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected the request body to contain %s, got %s", expected, body)
+		}
+	}
+	if strings.Contains(string(body), "notify_watch_recipients") {
+		t.Errorf("expected unset actions to be left out, got %s", body)
+	}
+
+	var policy xrayPolicy
+	if err := json.Unmarshal(body, &policy); err != nil {
+		t.Fatal(err)
+	}
+	actions := flattenRules(*policy.Rules)[0].(map[string]interface{})["actions"].([]interface{})[0].(map[string]interface{})
+	if actions["build_failure_grace_period_in_days"] != 7 || actions["notify_deployer"] != false {
+		t.Errorf("expected the extended actions to be flattened, got %v", actions)
+	}
+}
+
+func TestExpandPolicy_licenseCriteria(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceXrayPolicy().Schema, map[string]interface{}{
+		"name": "test-policy",
+		"type": "license",
+		"rules": []interface{}{map[string]interface{}{
+			"name":     "test-rule",
+			"priority": 1,
+			"criteria": []interface{}{map[string]interface{}{
+				"banned_licenses":           []interface{}{"MIT"},
+				"banned_license_categories": []interface{}{"copyleft"},
+			}},
+		}},
+	})
+
+	criteria := (*expandPolicy(d).Rules)[0].Criteria
+	if criteria.AllowUnkown == nil || *criteria.AllowUnkown {
+		t.Errorf("expected allow_unknown to be sent as false, got %v", criteria.AllowUnkown)
+	}
+	if criteria.MinimumSeverity != nil || criteria.MultiLicensePermissive != nil || criteria.AllowedLicenses != nil {
+		t.Errorf("expected only the license criteria that are set to be sent, got %+v", criteria)
+	}
+	banned := *criteria.BannedLicenses
+	if banned[0] != "MIT" || len(banned) != len(licensesInCategories([]string{"copyleft"}))+1 {
+		t.Errorf("expected MIT followed by every copyleft license, got %v", banned)
+	}
+
+	// Reading the policy back shouldn't show the licenses the category was expanded into
+	rules := flattenRules(*expandPolicy(d).Rules)
+	expanded := restoreLicenseCategories(rules, d.Get("rules").([]interface{}))
+	m := rules[0].(map[string]interface{})["criteria"].([]interface{})[0].(map[string]interface{})
+	if l := m["banned_licenses"].([]string); len(l) != 1 || l[0] != "MIT" {
+		t.Errorf("expected only the explicitly banned license to be read back, got %v", l)
+	}
+	if c := m["banned_license_categories"].([]interface{}); len(c) != 1 || c[0] != "copyleft" {
+		t.Errorf("expected the category to be kept, got %v", c)
+	}
+
+	// The licenses moved out of the list have to match what the category stands for, or there would be a diff
+	var fromCategories []interface{}
+	for _, l := range expanded[0].(map[string]interface{})["banned_licenses"].([]string) {
+		fromCategories = append(fromCategories, l)
+	}
+	if expected := licenseExpansions(d.Get("rules").([]interface{})); !reflect.DeepEqual(fromCategories, expected[0].(map[string]interface{})["banned_licenses"]) {
+		t.Errorf("expected the licenses read back to match the expansion %v, got %v", expected, expanded)
+	}
+}
+
 func testAccCheckPolicyDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*xray.Xray)
+	conn := testAccProvider.Meta().(*xrayClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "xray_policy" {
@@ -279,6 +999,180 @@ resource "xray_policy" "test" {
 `, name, description, ruleName, rangeTo)
 }
 
+func testAccXrayPolicy_packageCriteria(name, versions string) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "test" {
+	name  = "%s"
+	description = "policy created by xray acceptance tests"
+	type = "security"
+
+	rules {
+		name = "log4shell"
+		priority = 1
+		criteria {
+			vulnerability_ids = ["CVE-2021-44228"]
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = true
+			}
+		}
+	}
+
+	rules {
+		name = "log4j-core"
+		priority = 2
+		criteria {
+			package_type = "maven"
+			package_name = "org.apache.logging.log4j:log4j-core"
+			package_versions = ["%s"]
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = true
+			}
+		}
+	}
+
+	rules {
+		name = "fixable-high"
+		priority = 3
+		criteria {
+			min_severity = "High"
+			fix_version_dependant = true
+			applicable_cves_only = false
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = false
+			}
+		}
+	}
+}
+`, name, versions)
+}
+
+func testAccXrayPolicy_exposures(name, minSeverity string, iac bool) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "test" {
+	name  = "%s"
+	description = "policy created by xray acceptance tests"
+	type = "security"
+
+	rules {
+		name = "exposures"
+		priority = 1
+		criteria {
+			exposures {
+				min_severity = "%s"
+				secrets = true
+				iac = %t
+			}
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = true
+			}
+		}
+	}
+
+	rules {
+		name = "malicious"
+		priority = 2
+		criteria {
+			malicious_package = true
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = true
+			}
+		}
+	}
+}
+`, name, minSeverity, iac)
+}
+
+func testAccXrayPolicy_exposuresInvalid(name string) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "test" {
+	name  = "%s"
+	type = "security"
+
+	rules {
+		name = "exposures"
+		priority = 1
+		criteria {
+			exposures {
+				min_severity = "High"
+			}
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = true
+			}
+		}
+	}
+}
+`, name)
+}
+
+func testAccXrayPolicy_licenseCategories(name, categories string) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "test" {
+	name  = "%s"
+	description = "policy created by xray acceptance tests"
+	type = "license"
+
+	rules {
+		name = "no-copyleft"
+		priority = 1
+		criteria {
+			banned_licenses = ["MIT"]
+			banned_license_categories = [%s]
+			multi_license_permissive = true
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = false
+			}
+		}
+	}
+}
+`, name, categories)
+}
+
+func testAccXrayPolicy_extraJSON(name, extra string) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "test" {
+	name  = "%s"
+	description = "policy created by xray acceptance tests"
+	type = "security"
+	extra_json = %q
+
+	rules {
+		name = "high"
+		priority = 1
+		criteria {
+			min_severity = "High"
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = true
+			}
+		}
+	}
+}
+`, name, extra)
+}
+
 func testAccXrayPolicy_allActions(name, description, ruleName, email string) string {
 // Except for webhooks, because the API won't let you test with junk urls: Error: {"error":"Rule test-security-rule triggers an unrecognized webhook https://example.com"}
 	return fmt.Sprintf(`
@@ -301,6 +1195,13 @@ resource "xray_policy" "test" {
 			}
 			mails = ["%s"]
 			custom_severity = "High"
+			notify_watch_recipients = true
+			notify_deployer = true
+			create_ticket_enabled = false
+			build_failure_grace_period_in_days = 5
+			block_release_bundle_distribution = true
+			block_release_bundle_promotion = false
+			fail_pull_request = true
 		}
 	}
 }
@@ -357,6 +1258,67 @@ resource "xray_policy" "test" {
 `, name, description, ruleName, bannedLicense1, bannedLicense2)
 }
 
+func testAccXrayPolicy_forceDetach(name string) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "test" {
+	name  = "%s"
+	description = "policy created by xray acceptance tests"
+	type = "security"
+	force_detach = true
+
+	rules {
+		name = "rule-name"
+		priority = 1
+		criteria {
+			min_severity = "High"
+		}
+		actions {
+			block_download {
+				unscanned = true
+				active = true
+			}
+		}
+	}
+}
+`, name)
+}
+
+func testAccXrayPolicy_withWatch(name, watchName string) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "test" {
+	name  = "%s"
+	description = "policy created by xray acceptance tests"
+	type = "security"
+
+	rules {
+		name = "rule-name"
+		priority = 1
+		criteria {
+			min_severity = "High"
+		}
+		actions {
+			block_download {
+				unscanned = true
+				active = true
+			}
+		}
+	}
+}
+
+resource "xray_watch" "test" {
+	name  = "%s"
+	resources {
+		type = "all-repos"
+		name = "All Repositories"
+	}
+	assigned_policies {
+		name = xray_policy.test.name
+		type = "security"
+	}
+}
+`, name, watchName)
+}
+
 func testAccXrayPolicy_missingBlockDownloads(name, description, ruleName string) string {
 	return fmt.Sprintf(`
 resource "xray_policy" "test" {
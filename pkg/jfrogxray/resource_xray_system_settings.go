@@ -0,0 +1,110 @@
+package jfrogxray
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// There's only one set of settings, so every instance of the resource has the same ID
+const systemSettingsID = "xray_settings"
+
+// Manages Xray's basic settings. The settings always exist, so creating the resource takes them over and destroying
+// it leaves them as they are. Settings that aren't configured keep whatever value they have in Xray.
+func resourceXraySystemSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceXraySystemSettingsUpdate,
+		Read:   resourceXraySystemSettingsRead,
+		Update: resourceXraySystemSettingsUpdate,
+		Delete: resourceXraySystemSettingsDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: importSingletonState(systemSettingsID),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"allow_blocked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"allow_when_unavailable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"block_unscanned_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"block_unfinished_scans_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"build_retention_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+	}
+}
+
+func resourceXraySystemSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	settings, _, err := c.getSettings(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{
+		"enabled":                        settings.Enabled,
+		"allow_blocked":                  settings.AllowBlocked,
+		"allow_when_unavailable":         settings.AllowWhenUnavailable,
+		"block_unscanned_timeout":        settings.BlockUnscannedTimeoutSeconds,
+		"block_unfinished_scans_timeout": settings.BlockUnfinishedScansTimeoutSeconds,
+		"build_retention_days":           settings.BuildRetentionDays,
+	}
+	for k, v := range fields {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceXraySystemSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	settings := &xraySettings{
+		Enabled:                            getOptionalBool(d, "enabled"),
+		AllowBlocked:                       getOptionalBool(d, "allow_blocked"),
+		AllowWhenUnavailable:               getOptionalBool(d, "allow_when_unavailable"),
+		BlockUnscannedTimeoutSeconds:       getOptionalInt(d, "block_unscanned_timeout"),
+		BlockUnfinishedScansTimeoutSeconds: getOptionalInt(d, "block_unfinished_scans_timeout"),
+		BuildRetentionDays:                 getOptionalInt(d, "build_retention_days"),
+	}
+	if _, err := c.updateSettings(context.Background(), settings); err != nil {
+		return err
+	}
+
+	d.SetId(systemSettingsID)
+	return resourceXraySystemSettingsRead(d, meta)
+}
+
+// Xray always has settings, so destroying the resource only stops managing them
+func resourceXraySystemSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
@@ -0,0 +1,85 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccSystemSettings_basic(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_system_settings.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(`
+resource "xray_system_settings" "test" {
+	allow_when_unavailable  = true
+	block_unscanned_timeout = 120
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "xray_settings"),
+					resource.TestCheckResourceAttr(resourceName, "allow_when_unavailable", "true"),
+					resource.TestCheckResourceAttr(resourceName, "block_unscanned_timeout", "120"),
+					// Settings that aren't configured are read from Xray
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "build_retention_days", "90"),
+				),
+			},
+			{
+				Config: server.config(`
+resource "xray_system_settings" "test" {
+	allow_when_unavailable  = false
+	block_unscanned_timeout = 0
+	build_retention_days    = 30
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "allow_when_unavailable", "false"),
+					resource.TestCheckResourceAttr(resourceName, "block_unscanned_timeout", "0"),
+					resource.TestCheckResourceAttr(resourceName, "build_retention_days", "30"),
+					func(*terraform.State) error {
+						server.mu.Lock()
+						defer server.mu.Unlock()
+						if v := server.settings["blockUnscannedTimeoutSeconds"]; v != float64(0) {
+							return fmt.Errorf("expected the timeout to be turned off, got %v", v)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: server.config(`
+resource "xray_system_settings" "test" {
+	allow_when_unavailable  = false
+	block_unscanned_timeout = 0
+	build_retention_days    = 30
+}
+`),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "settings",
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig: server.edit(func() {
+					server.settings["allowWhenUnavailable"] = true
+				}),
+				Config: server.config(`
+resource "xray_system_settings" "test" {
+	allow_when_unavailable  = false
+	block_unscanned_timeout = 0
+	build_retention_days    = 30
+}
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -2,10 +2,13 @@ package jfrogxray
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/xero-oss/go-xray/xray"
 	v2 "github.com/xero-oss/go-xray/xray/v2"
 )
@@ -17,6 +20,8 @@ func resourceXrayWatch() *schema.Resource {
 		Update: resourceXrayWatchUpdate,
 		Delete: resourceXrayWatchDelete,
 
+		CustomizeDiff: resourceXrayWatchCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -25,7 +30,6 @@ func resourceXrayWatch() *schema.Resource {
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"description": {
 				Type:     schema.TypeString,
@@ -42,8 +46,9 @@ func resourceXrayWatch() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(validWatchResources, false),
 						},
 						"name": {
 							Type:     schema.TypeString,
@@ -59,8 +64,9 @@ func resourceXrayWatch() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"type": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(validWatchFilters, false),
 									},
 									// TODO this can be either a string or possibly a json blob
 									// eg "value":{"ExcludePatterns":[],"IncludePatterns":["*"]}
@@ -77,7 +83,7 @@ func resourceXrayWatch() *schema.Resource {
 
 			"assigned_policies": {
 				Type:     schema.TypeList,
-				Required: true,
+				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -85,16 +91,24 @@ func resourceXrayWatch() *schema.Resource {
 							Required: true,
 						},
 						"type": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validatePolicyType,
 						},
 					},
 				},
 			},
 
+			"extra_json": extraJSONSchema(),
+			"ignore_external_assignments": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"watch_recipients": {
-				Type:          schema.TypeList,
-				Optional:      true,
+				Type:     schema.TypeList,
+				Optional: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -103,18 +117,58 @@ func resourceXrayWatch() *schema.Resource {
 	}
 }
 
-func expandWatch(d *schema.ResourceData) *v2.Watch {
-	watch := new(v2.Watch)
+// Assigned policies are checked at plan time when they already exist. Policies created in the same run
+// can't be looked up yet, so they are checked again right before the watch is created or updated.
+func resourceXrayWatchCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if meta == nil || !d.NewValueKnown("name") || !d.NewValueKnown("assigned_policies") {
+		return nil
+	}
+
+	return checkAssignedPolicies(meta.(*xrayClient), d.Get("name").(string), d.Get("assigned_policies").([]interface{}), false)
+}
+
+// checkAssignedPolicies makes sure every assigned policy has the type it is assigned with. When mustExist is
+// false, policies that can't be found (or looked up) are skipped rather than reported.
+func checkAssignedPolicies(c *xrayClient, watchName string, policies []interface{}, mustExist bool) error {
+	var errs *multierror.Error
+
+	for _, raw := range policies {
+		cfg := raw.(map[string]interface{})
+		name := cfg["name"].(string)
+		policyType := cfg["type"].(string)
+
+		policy, resp, err := c.V1.Policies.GetPolicy(context.Background(), name)
+		if policyNotFound(resp, err, name) {
+			if mustExist {
+				errs = multierror.Append(errs, fmt.Errorf("watch %q: assigned policy %q does not exist", watchName, name))
+			}
+			continue
+		} else if err != nil {
+			if mustExist {
+				errs = multierror.Append(errs, fmt.Errorf("watch %q: failed to look up assigned policy %q: %s", watchName, name, err))
+			} else {
+				log.Printf("[WARN] Unable to check policy (%s) assigned to watch (%s): %s", name, watchName, err)
+			}
+			continue
+		}
+
+		if policy.Type != nil && *policy.Type != policyType {
+			errs = multierror.Append(errs, fmt.Errorf("watch %q: assigned policy %q is a %s policy but is assigned with type %q", watchName, name, *policy.Type, policyType))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func expandWatch(d *schema.ResourceData) *xrayWatch {
+	watch := new(xrayWatch)
+	watch.extraJSON = d.Get("extra_json").(string)
 
 	gd := &v2.WatchGeneralData{
 		Name: xray.String(d.Get("name").(string)),
 	}
-	if v, ok := d.GetOk("description"); ok {
-		gd.Description = xray.String(v.(string))
-	}
-	if v, ok := d.GetOk("active"); ok {
-		gd.Active = xray.Bool(v.(bool))
-	}
+	gd.Description = getOptionalString(d, "description")
+	gd.Active = getOptionalBool(d, "active")
 	watch.GeneralData = gd
 
 	pr := &v2.WatchProjectResources{}
@@ -240,12 +294,120 @@ func flattenAssignedPolicies(policies *[]v2.WatchAssignedPolicy) []interface{} {
 	return l
 }
 
+// findWatchesWithPolicy returns the names of all watches the given policy is assigned to
+func findWatchesWithPolicy(c *xrayClient, policyName string) ([]string, error) {
+	watches, _, err := c.V2.Watches.ListWatches(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, watch := range *watches {
+		if watch.GeneralData == nil || watch.GeneralData.Name == nil || watch.AssignedPolicies == nil {
+			continue
+		}
+		for _, p := range *watch.AssignedPolicies {
+			if p.Name != nil && *p.Name == policyName {
+				names = append(names, *watch.GeneralData.Name)
+				break
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// replaceWatchPolicy swaps the assignment of one policy on a watch for another, leaving the rest of the watch
+// untouched. An empty replacement removes the assignment altogether.
+func replaceWatchPolicy(c *xrayClient, watchName, policyName, replacement string) error {
+	watchMutexKV.Lock(watchName)
+	defer watchMutexKV.Unlock(watchName)
+
+	watch, _, err := c.getWatch(context.Background(), watchName)
+	if err != nil {
+		return err
+	}
+
+	policies := []v2.WatchAssignedPolicy{}
+	if watch.AssignedPolicies != nil {
+		for _, p := range *watch.AssignedPolicies {
+			if p.Name != nil && *p.Name == policyName {
+				if replacement == "" {
+					continue
+				}
+				p.Name = xray.String(replacement)
+			}
+			policies = append(policies, p)
+		}
+	}
+	watch.AssignedPolicies = &policies
+
+	if _, err := c.updateWatch(context.Background(), watchName, watch); err != nil {
+		if replacement == "" {
+			return fmt.Errorf("failed to detach policy %q from watch %q: %s", policyName, watchName, err)
+		}
+		return fmt.Errorf("failed to reassign watch %q from policy %q to %q: %s", watchName, policyName, replacement, err)
+	}
+	return nil
+}
+
+func assignedPolicyNames(policies []interface{}) map[string]bool {
+	names := map[string]bool{}
+	for _, raw := range policies {
+		names[raw.(map[string]interface{})["name"].(string)] = true
+	}
+	return names
+}
+
+// filterAssignedPolicies drops every flattened assignment whose policy isn't in names
+func filterAssignedPolicies(policies []interface{}, names map[string]bool) []interface{} {
+	l := []interface{}{}
+	for _, raw := range policies {
+		if name := raw.(map[string]interface{})["name"].(*string); name != nil && names[*name] {
+			l = append(l, raw)
+		}
+	}
+	return l
+}
+
+// externalAssignedPolicies returns the assignments on the watch that this resource doesn't manage, i.e. ones that
+// are neither configured nor were managed by it before. They are carried over untouched on update.
+func externalAssignedPolicies(c *xrayClient, d *schema.ResourceData) ([]v2.WatchAssignedPolicy, error) {
+	current, _, err := c.V2.Watches.GetWatch(context.Background(), d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	old, new := d.GetChange("assigned_policies")
+	managed := assignedPolicyNames(new.([]interface{}))
+	// The old list only holds the managed assignments if it was read with the flag on. After an import, or when the
+	// flag was just turned on, it holds every assignment in Xray, so anything not configured is external.
+	if wasIgnoring, _ := d.GetChange("ignore_external_assignments"); wasIgnoring.(bool) {
+		for name := range assignedPolicyNames(old.([]interface{})) {
+			managed[name] = true
+		}
+	}
+
+	external := []v2.WatchAssignedPolicy{}
+	if current.AssignedPolicies != nil {
+		for _, p := range *current.AssignedPolicies {
+			if p.Name != nil && !managed[*p.Name] {
+				external = append(external, p)
+			}
+		}
+	}
+	return external, nil
+}
+
 func resourceXrayWatchCreate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*xray.Xray)
+	c := meta.(*xrayClient)
 
 	watch := expandWatch(d)
+	if err := checkAssignedPolicies(c, d.Get("name").(string), d.Get("assigned_policies").([]interface{}), true); err != nil {
+		return err
+	}
 
-	_, err := c.V2.Watches.CreateWatch(context.Background(), watch)
+	_, err := c.createWatch(context.Background(), watch)
 	if err != nil {
 		return err
 	}
@@ -255,10 +417,10 @@ func resourceXrayWatchCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceXrayWatchRead(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*xray.Xray)
+	c := meta.(*xrayClient)
 
-	watch, resp, err := c.V2.Watches.GetWatch(context.Background(), d.Id())
-	if resp.StatusCode == http.StatusNotFound {
+	watch, resp, err := c.getWatch(context.Background(), d.Id())
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
 		log.Printf("[WARN] Xray watch (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
@@ -275,7 +437,20 @@ func resourceXrayWatchRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("resources", flattenProjectResources(watch.ProjectResources)); err != nil {
 		return err
 	}
-	if err := d.Set("assigned_policies", flattenAssignedPolicies(watch.AssignedPolicies)); err != nil {
+	assigned := flattenAssignedPolicies(watch.AssignedPolicies)
+	// An import has no list of managed assignments to filter against, but it doesn't set the flag either, so it
+	// keeps every assignment and the first apply works out which of them are external
+	if d.Get("ignore_external_assignments").(bool) {
+		assigned = filterAssignedPolicies(assigned, assignedPolicyNames(d.Get("assigned_policies").([]interface{})))
+	}
+	if err := d.Set("assigned_policies", assigned); err != nil {
+		return err
+	}
+	extra, err := flattenExtraJSON(watch.response, d.Get("extra_json").(string), true)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("extra_json", extra); err != nil {
 		return err
 	}
 
@@ -283,11 +458,32 @@ func resourceXrayWatchRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceXrayWatchUpdate(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*xray.Xray)
+	c := meta.(*xrayClient)
 
 	watch := expandWatch(d)
-	_, err := c.V2.Watches.UpdateWatch(context.Background(), d.Id(), watch)
-	if err != nil {
+	if err := checkAssignedPolicies(c, d.Get("name").(string), d.Get("assigned_policies").([]interface{}), true); err != nil {
+		return err
+	}
+
+	if d.Get("ignore_external_assignments").(bool) {
+		watchMutexKV.Lock(d.Id())
+		defer watchMutexKV.Unlock(d.Id())
+
+		external, err := externalAssignedPolicies(c, d)
+		if err != nil {
+			return err
+		}
+		*watch.AssignedPolicies = append(*watch.AssignedPolicies, external...)
+	}
+
+	if d.HasChange("name") {
+		// Don't record any of the new values unless the whole rename goes through
+		d.Partial(true)
+		if err := renameWatch(c, d.Id(), watch); err != nil {
+			return err
+		}
+		d.Partial(false)
+	} else if _, err := c.updateWatch(context.Background(), d.Id(), watch); err != nil {
 		return err
 	}
 
@@ -295,8 +491,34 @@ func resourceXrayWatchUpdate(d *schema.ResourceData, meta interface{}) error {
 	return resourceXrayWatchRead(d, meta)
 }
 
+// renameWatch renames a watch in place if Xray allows it. Otherwise the watch is recreated under its new name
+// and the old one is deleted, rolling back if that fails.
+func renameWatch(c *xrayClient, oldName string, watch *xrayWatch) error {
+	newName := *watch.GeneralData.Name
+
+	if _, err := c.updateWatch(context.Background(), oldName, watch); err == nil {
+		if _, resp, err := c.getWatch(context.Background(), newName); err == nil && resp.StatusCode == http.StatusOK {
+			return nil
+		}
+	} else {
+		log.Printf("[DEBUG] Unable to rename Xray watch (%s) in place, recreating it as (%s): %s", oldName, newName, err)
+	}
+
+	if _, err := c.createWatch(context.Background(), watch); err != nil {
+		return fmt.Errorf("failed to create watch %q to replace %q: %s", newName, oldName, err)
+	}
+	if resp, err := c.V2.Watches.DeleteWatch(context.Background(), oldName); err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		if _, rollbackErr := c.V2.Watches.DeleteWatch(context.Background(), newName); rollbackErr != nil {
+			log.Printf("[WARN] Failed to roll back the creation of Xray watch (%s): %s", newName, rollbackErr)
+		}
+		return fmt.Errorf("failed to delete watch %q after renaming it to %q: %s", oldName, newName, err)
+	}
+
+	return nil
+}
+
 func resourceXrayWatchDelete(d *schema.ResourceData, meta interface{}) error {
-	c := meta.(*xray.Xray)
+	c := meta.(*xrayClient)
 
 	resp, err := c.V2.Watches.DeleteWatch(context.Background(), d.Id())
 	if resp.StatusCode == http.StatusNotFound {
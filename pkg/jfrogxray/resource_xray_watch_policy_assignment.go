@@ -0,0 +1,168 @@
+package jfrogxray
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	v2 "github.com/xero-oss/go-xray/xray/v2"
+)
+
+// Manages a single policy assignment on a watch that is otherwise managed elsewhere, so that teams owning
+// policies don't have to own the watch. The watch should set ignore_external_assignments if it is in Terraform too.
+func resourceXrayWatchPolicyAssignment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceXrayWatchPolicyAssignmentCreate,
+		Read:   resourceXrayWatchPolicyAssignmentRead,
+		Delete: resourceXrayWatchPolicyAssignmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: resourceXrayWatchPolicyAssignmentImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"watch_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validatePolicyType,
+			},
+		},
+	}
+}
+
+func watchPolicyAssignmentID(watchName, policyName string) string {
+	return fmt.Sprintf("%s:%s", watchName, policyName)
+}
+
+func parseWatchPolicyAssignmentID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected watch-name:policy-name", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func findAssignedPolicy(watch *v2.Watch, policyName string) *v2.WatchAssignedPolicy {
+	if watch.AssignedPolicies == nil {
+		return nil
+	}
+	for _, p := range *watch.AssignedPolicies {
+		if p.Name != nil && *p.Name == policyName {
+			return &p
+		}
+	}
+	return nil
+}
+
+func resourceXrayWatchPolicyAssignmentCreate(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	watchName := d.Get("watch_name").(string)
+	policyName := d.Get("policy_name").(string)
+	policyType := d.Get("policy_type").(string)
+
+	assignment := map[string]interface{}{"name": policyName, "type": policyType}
+	if err := checkAssignedPolicies(c, watchName, []interface{}{assignment}, true); err != nil {
+		return err
+	}
+
+	watchMutexKV.Lock(watchName)
+	defer watchMutexKV.Unlock(watchName)
+
+	watch, _, err := c.getWatch(context.Background(), watchName)
+	if err != nil {
+		return err
+	}
+	if findAssignedPolicy(&watch.Watch, policyName) != nil {
+		return fmt.Errorf("policy %q is already assigned to watch %q. Import it with ID %q to manage it", policyName, watchName, watchPolicyAssignmentID(watchName, policyName))
+	}
+
+	policies := []v2.WatchAssignedPolicy{}
+	if watch.AssignedPolicies != nil {
+		policies = append(policies, *watch.AssignedPolicies...)
+	}
+	policies = append(policies, *expandAssignedPolicy(assignment))
+	watch.AssignedPolicies = &policies
+
+	if _, err := c.updateWatch(context.Background(), watchName, watch); err != nil {
+		return err
+	}
+
+	d.SetId(watchPolicyAssignmentID(watchName, policyName))
+	return resourceXrayWatchPolicyAssignmentRead(d, meta)
+}
+
+func resourceXrayWatchPolicyAssignmentRead(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	watchName, policyName, err := parseWatchPolicyAssignmentID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	watch, resp, err := c.V2.Watches.GetWatch(context.Background(), watchName)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		log.Printf("[WARN] Xray watch (%s) not found, removing policy assignment from state", watchName)
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	policy := findAssignedPolicy(watch, policyName)
+	if policy == nil {
+		log.Printf("[WARN] Xray policy (%s) is no longer assigned to watch (%s), removing from state", policyName, watchName)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("watch_name", watchName); err != nil {
+		return err
+	}
+	if err := d.Set("policy_name", policyName); err != nil {
+		return err
+	}
+	if err := d.Set("policy_type", policy.Type); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceXrayWatchPolicyAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
+	c := meta.(*xrayClient)
+
+	watchName, policyName, err := parseWatchPolicyAssignmentID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	_, resp, err := c.V2.Watches.GetWatch(context.Background(), watchName)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return replaceWatchPolicy(c, watchName, policyName, "")
+}
+
+func resourceXrayWatchPolicyAssignmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseWatchPolicyAssignmentID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
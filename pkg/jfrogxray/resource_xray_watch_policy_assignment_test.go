@@ -0,0 +1,100 @@
+package jfrogxray
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccWatchPolicyAssignment_basic(t *testing.T) {
+	watchName := "test-watch"
+	watchPolicyName := "test-watch-policy"
+	assignedPolicyName := "test-assigned-policy"
+	resourceName := "xray_watch_policy_assignment.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckWatchDestroy,
+		Providers:    testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccXrayWatchPolicyAssignment_basic(watchName, watchPolicyName, assignedPolicyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", fmt.Sprintf("%s:%s", watchName, assignedPolicyName)),
+					resource.TestCheckResourceAttr(resourceName, "policy_type", "license"),
+					// The watch only reports the assignment it manages itself
+					resource.TestCheckResourceAttr("xray_watch.test", "assigned_policies.#", "1"),
+					resource.TestCheckResourceAttr("xray_watch.test", "assigned_policies.0.name", watchPolicyName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccXrayWatchPolicyAssignment_basic(watchName, watchPolicyName, assignedPolicyName string) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "watch" {
+	name  = "%s"
+	type = "security"
+
+	rules {
+		name = "rule-name"
+		priority = 1
+		criteria {
+			min_severity = "High"
+		}
+		actions {
+			block_download {
+				unscanned = true
+				active = true
+			}
+		}
+	}
+}
+
+resource "xray_policy" "assigned" {
+	name  = "%s"
+	type = "license"
+
+	rules {
+		name = "rule-name"
+		priority = 1
+		criteria {
+			allow_unknown = true
+			banned_licenses = ["AGPL-3.0-only"]
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = true
+			}
+		}
+	}
+}
+
+resource "xray_watch" "test" {
+	name  = "%s"
+	resources {
+		type = "all-repos"
+		name = "All Repositories"
+	}
+	assigned_policies {
+		name = xray_policy.watch.name
+		type = "security"
+	}
+	ignore_external_assignments = true
+}
+
+resource "xray_watch_policy_assignment" "test" {
+	watch_name  = xray_watch.test.name
+	policy_name = xray_policy.assigned.name
+	policy_type = "license"
+}
+`, watchPolicyName, assignedPolicyName, watchName)
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/xero-oss/go-xray/xray"
 )
 
 func TestAccWatch_basic(t *testing.T) {
@@ -47,6 +48,248 @@ func TestAccWatch_basic(t *testing.T) {
 	})
 }
 
+func TestAccWatch_rename(t *testing.T) {
+	server := newTestXrayServer(t)
+	watchName := "test-watch"
+	renamedWatchName := "test-watch-renamed"
+	policyName := "test-policy"
+	watchDesc := "watch created by xray acceptance tests"
+	resourceName := "xray_watch.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayWatch_basic(watchName, watchDesc, policyName)),
+				Check:  resource.TestCheckResourceAttr(resourceName, "id", watchName),
+			},
+			{
+				Config: server.config(testAccXrayWatch_basic(renamedWatchName, watchDesc, policyName)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", renamedWatchName),
+					resource.TestCheckResourceAttr(resourceName, "name", renamedWatchName),
+					resource.TestCheckResourceAttr(resourceName, "assigned_policies.0.name", policyName),
+					func(*terraform.State) error {
+						if server.watch(watchName) != nil || server.watch(renamedWatchName) == nil {
+							return fmt.Errorf("expected watch %s to be renamed to %s", watchName, renamedWatchName)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: server.config(testAccXrayWatch_unassigned(policyName)),
+				Check: func(*terraform.State) error {
+					if server.watch(renamedWatchName) != nil {
+						return fmt.Errorf("expected watch %s to be deleted", renamedWatchName)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccWatch_extraJSON(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_watch.test"
+
+	futureSetting := func(expected interface{}) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			general := server.watch("test-watch")["general_data"].(map[string]interface{})
+			if general["future_setting"] != expected {
+				return fmt.Errorf("expected future_setting to be %v, got %v", expected, general["future_setting"])
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayWatch_extraJSON("test-watch", false)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "extra_json", `{"general_data":{"future_setting":true}}`),
+					futureSetting(true),
+				),
+			},
+			{
+				// Assigning a policy from outside the watch resource has to keep the extra field
+				Config: server.config(testAccXrayWatch_extraJSON("test-watch", true)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "assigned_policies.#", "1"),
+					futureSetting(true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWatch_ignoreExternalAssignments(t *testing.T) {
+	server := newTestXrayServer(t)
+	resourceName := "xray_watch.test"
+
+	assignedPolicies := func(expected int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			policies, _ := server.watch("test-watch")["assigned_policies"].([]interface{})
+			if len(policies) != expected {
+				return fmt.Errorf("expected %d policies assigned in Xray, got %d", expected, len(policies))
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		CheckDestroy: server.checkDestroyed,
+		Providers:    server.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: server.config(testAccXrayWatch_ignoreExternalAssignments(false)),
+				Check:  assignedPolicies(1),
+			},
+			{
+				// The state lists the external assignment as well, as it would after an import. Turning the flag
+				// on has to keep it in Xray.
+				PreConfig: server.edit(func() {
+					watch := server.watches["test-watch"]
+					watch["assigned_policies"] = append(watch["assigned_policies"].([]interface{}),
+						map[string]interface{}{"name": "test-policy-second", "type": "license"})
+				}),
+				Config: server.config(testAccXrayWatch_ignoreExternalAssignments(true)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "assigned_policies.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "assigned_policies.0.name", "test-policy-first"),
+					assignedPolicies(2),
+				),
+			},
+			{
+				Config:   server.config(testAccXrayWatch_ignoreExternalAssignments(true)),
+				PlanOnly: true,
+			},
+			{
+				Config:        server.config(testAccXrayWatch_ignoreExternalAssignments(true)),
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "test-watch",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if n := states[0].Attributes["assigned_policies.#"]; n != "2" {
+						return fmt.Errorf("expected the import to keep both assignments, got %s", n)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccWatch_deactivate(t *testing.T) {
+	watchName := "test-watch"
+	policyName := "test-policy"
+	watchDesc := "watch created by xray acceptance tests"
+	resourceName := "xray_watch.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckWatchDestroy,
+		Providers:    testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccXrayWatch_active(watchName, watchDesc, policyName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "active", "true"),
+					resource.TestCheckResourceAttr(resourceName, "description", watchDesc),
+				),
+			},
+			{
+				Config: testAccXrayWatch_active(watchName, "", policyName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "active", "false"),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+				),
+			},
+			{
+				Config: testAccXrayWatch_unassigned(policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWatchDoesntExist(resourceName),
+				),
+			},
+		},
+	})
+}
+
+// testResourceDataUpdate builds the ResourceData an update from state to raw would be applied with
+func testResourceDataUpdate(t *testing.T, r *schema.Resource, state map[string]string, raw map[string]interface{}) *schema.ResourceData {
+	is := &terraform.InstanceState{ID: "test", Attributes: state}
+	diff, err := r.Diff(is, terraform.NewResourceConfigRaw(raw), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(is, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestExpandWatch_zeroValues(t *testing.T) {
+	config := map[string]interface{}{
+		"name": "test-watch",
+		"resources": []interface{}{
+			map[string]interface{}{"type": "all-repos", "name": "All Repositories"},
+		},
+	}
+	state := map[string]string{
+		"name":             "test-watch",
+		"resources.#":      "1",
+		"resources.0.type": "all-repos",
+		"resources.0.name": "All Repositories",
+	}
+
+	// Leaving them out of a new watch sends nothing
+	watch := expandWatch(schema.TestResourceDataRaw(t, resourceXrayWatch().Schema, config))
+	if watch.GeneralData.Active != nil || watch.GeneralData.Description != nil {
+		t.Errorf("expected active and description to be left out, got %v and %v", watch.GeneralData.Active, watch.GeneralData.Description)
+	}
+
+	// An explicit false is sent on create
+	config["active"] = false
+	watch = expandWatch(schema.TestResourceDataRaw(t, resourceXrayWatch().Schema, config))
+	if watch.GeneralData.Active == nil || *watch.GeneralData.Active {
+		t.Errorf("expected active to be sent as false, got %v", watch.GeneralData.Active)
+	}
+
+	// Deactivating the watch and removing its description sends both zero values
+	state["active"] = "true"
+	state["description"] = "watch description"
+	watch = expandWatch(testResourceDataUpdate(t, resourceXrayWatch(), state, config))
+	if watch.GeneralData.Active == nil || *watch.GeneralData.Active {
+		t.Errorf("expected active to be sent as false, got %v", watch.GeneralData.Active)
+	}
+	if watch.GeneralData.Description == nil || *watch.GeneralData.Description != "" {
+		t.Errorf("expected description to be sent as empty, got %v", watch.GeneralData.Description)
+	}
+}
+
+func TestAccWatch_policyTypeMismatch(t *testing.T) {
+	watchName := "test-watch"
+	policyName := "test-policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckWatchDestroy,
+		Providers:    testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccXrayWatch_policyType(watchName, policyName, "license"),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`watch "%s": assigned policy "%s" is a security policy but is assigned with type "license"`, watchName, policyName)),
+			},
+		},
+	})
+}
+
 // These two tests are commented out because repoName and binMgrId must be real values but neither are terraformable so can't be put into these tests
 // I have tested this with some real values, but for obvious privacy reasons am not leaving those real values in here
 /*func TestAccWatch_filters(t *testing.T) {
@@ -148,7 +391,7 @@ func testAccCheckWatchDoesntExist(resourceName string) resource.TestCheckFunc {
 }
 
 func testAccCheckWatchDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*xray.Xray)
+	conn := testAccProvider.Meta().(*xrayClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type == "xray_watch" {
@@ -219,6 +462,84 @@ resource "xray_watch" "test" {
 `, policyName, name, description)
 }
 
+func testAccXrayWatch_policyType(name, policyName, assignedType string) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "test" {
+	name  = "%s"
+	description = "test policy description"
+	type = "security"
+
+	rules {
+		name = "rule-name"
+		priority = 1
+		criteria {
+			min_severity = "High"
+		}
+		actions {
+			block_download {
+				unscanned = true
+				active = true
+			}
+		}
+	}
+}
+
+resource "xray_watch" "test" {
+	name  = "%s"
+	resources {
+		type = "all-repos"
+		name = "All Repositories"
+	}
+	assigned_policies {
+		name = xray_policy.test.name
+		type = "%s"
+	}
+}
+`, policyName, name, assignedType)
+}
+
+func testAccXrayWatch_active(name, description, policyName string, active bool) string {
+	desc := ""
+	if description != "" {
+		desc = fmt.Sprintf("description = %q", description)
+	}
+	return fmt.Sprintf(`
+resource "xray_policy" "test" {
+	name  = "%s"
+	description = "test policy description"
+	type = "security"
+
+	rules {
+		name = "rule-name"
+		priority = 1
+		criteria {
+			min_severity = "High"
+		}
+		actions {
+			block_download {
+				unscanned = true
+				active = true
+			}
+		}
+	}
+}
+
+resource "xray_watch" "test" {
+	name  = "%s"
+	%s
+	active = %t
+	resources {
+		type = "all-repos"
+		name = "All Repositories"
+	}
+	assigned_policies {
+		name = xray_policy.test.name
+		type = "security"
+	}
+}
+`, policyName, name, desc, active)
+}
+
 // Since policies can't be deleted if they have a watch assigned, we need to force terraform to delete the watch first
 // by removing it from the code at the end of every test step
 func testAccXrayWatch_unassigned(policyName string) string {
@@ -326,4 +647,134 @@ resource "xray_watch" "test" {
 `, policyName, name, description, binMgrId)
 }
 
-// TODO for bonus points - test builds with complex filters eg "filters":[{"type":"ant-patterns","value":{"ExcludePatterns":[],"IncludePatterns":["*"]}
\ No newline at end of file
+func testAccXrayWatch_extraJSON(name string, assignment bool) string {
+	config := fmt.Sprintf(`
+resource "xray_policy" "first" {
+	name  = "test-policy-first"
+	type = "security"
+
+	rules {
+		name = "rule-name"
+		priority = 1
+		criteria {
+			min_severity = "High"
+		}
+		actions {
+			block_download {
+				unscanned = true
+				active = true
+			}
+		}
+	}
+}
+
+resource "xray_watch" "test" {
+	name  = "%s"
+	ignore_external_assignments = true
+	extra_json = jsonencode({
+		general_data = {
+			future_setting = true
+		}
+	})
+
+	resources {
+		type = "all-repos"
+		name = "All Repositories"
+	}
+	assigned_policies {
+		name = xray_policy.first.name
+		type = "security"
+	}
+}
+`, name)
+	if assignment {
+		config += `
+resource "xray_policy" "second" {
+	name  = "test-policy-second"
+	type = "license"
+
+	rules {
+		name = "rule-name"
+		priority = 1
+		criteria {
+			allowed_licenses = ["MIT"]
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active = false
+			}
+		}
+	}
+}
+
+resource "xray_watch_policy_assignment" "second" {
+	watch_name  = xray_watch.test.name
+	policy_name = xray_policy.second.name
+	policy_type = "license"
+}
+`
+	}
+	return config
+}
+
+func testAccXrayWatch_ignoreExternalAssignments(ignore bool) string {
+	return fmt.Sprintf(`
+resource "xray_policy" "first" {
+	name = "test-policy-first"
+	type = "security"
+
+	rules {
+		name     = "rule-name"
+		priority = 1
+		criteria {
+			min_severity = "High"
+		}
+		actions {
+			block_download {
+				unscanned = true
+				active    = true
+			}
+		}
+	}
+}
+
+resource "xray_policy" "second" {
+	name = "test-policy-second"
+	type = "license"
+
+	rules {
+		name     = "rule-name"
+		priority = 1
+		criteria {
+			allowed_licenses = ["MIT"]
+		}
+		actions {
+			block_download {
+				unscanned = false
+				active    = false
+			}
+		}
+	}
+}
+
+resource "xray_watch" "test" {
+	name                        = "test-watch"
+	ignore_external_assignments = %t
+
+	resources {
+		type = "all-repos"
+		name = "All Repositories"
+	}
+	assigned_policies {
+		name = xray_policy.first.name
+		type = "security"
+	}
+
+	# The second policy is assigned behind Terraform's back, so it can only be deleted once the watch is gone
+	depends_on = [xray_policy.second]
+}
+`, ignore)
+}
+
+// TODO for bonus points - test builds with complex filters eg "filters":[{"type":"ant-patterns","value":{"ExcludePatterns":[],"IncludePatterns":["*"]}
@@ -0,0 +1,954 @@
+package jfrogxray
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// testXrayServer is a stand-in for the parts of the Xray API the provider uses, so that resources can be tested
+// without an Xray instance. Objects are stored as decoded JSON and aren't validated beyond what the handlers need.
+type testXrayServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	policies map[string]map[string]interface{}
+	watches  map[string]map[string]interface{}
+
+	// objects backs a made-up endpoint with numeric IDs, for testing xray_api_object
+	objects  map[string]map[string]interface{}
+	objectID int
+
+	// violations are set up by the tests, oldest first
+	violations []map[string]interface{}
+
+	// components are the summaries of known components, keyed by component ID
+	components       map[string]map[string]interface{}
+	operationalRisks map[string]map[string]interface{}
+
+	// artifacts are the summaries of known artifacts, keyed by path (starting with "default/") and by checksum.
+	// scanStatuses lists the statuses an artifact goes through, by path, with the last one sticking.
+	artifacts    map[string]map[string]interface{}
+	scanStatuses map[string][]string
+
+	// builds are the scan results of known builds, keyed by name/number
+	builds map[string]*testBuild
+
+	// graphIssues are the vulnerabilities of components found by graph scans, which are in progress for the first
+	// graphPolls polls. graphScans keeps the graphs that were scanned, and the watches they were scanned for.
+	graphIssues  map[string]map[string]interface{}
+	graphPolls   int
+	graphScans   []testGraphScan
+	graphPending map[string]int
+
+	// exports are the SBOM export requests, which succeed for the paths of known artifacts
+	exports []xrayExportRequest
+
+	// events are the issues in the Xray database, keyed by issue ID
+	events map[string]map[string]interface{}
+
+	// settings are Xray's basic settings, which start out as Xray's defaults
+	settings map[string]interface{}
+
+	// dbSync and systemParameters start out as Xray's defaults. offlineUpdates are the imports of the uploaded
+	// bundles, in upload order.
+	dbSyncTime       string
+	dbSync           map[string]interface{}
+	systemParameters map[string]interface{}
+	offlineUpdates   []*testOfflineUpdate
+}
+
+// testOfflineUpdate is in progress for the first polls, and fails for a bundle that isn't a zip archive
+type testOfflineUpdate struct {
+	bundle []byte
+	polls  int
+}
+
+type testGraphScan struct {
+	graph   xrayGraphNode
+	watches []string
+	project string
+}
+
+// testBuild is reported as in progress for the first polls after every scan
+type testBuild struct {
+	polls   int
+	pending int
+	scans   int
+	result  map[string]interface{}
+}
+
+func newTestXrayServer(t *testing.T) *testXrayServer {
+	s := &testXrayServer{
+		policies: map[string]map[string]interface{}{},
+		watches:  map[string]map[string]interface{}{},
+		objects:  map[string]map[string]interface{}{},
+
+		components:       map[string]map[string]interface{}{},
+		operationalRisks: map[string]map[string]interface{}{},
+
+		artifacts:    map[string]map[string]interface{}{},
+		scanStatuses: map[string][]string{},
+
+		builds: map[string]*testBuild{},
+
+		graphIssues:  map[string]map[string]interface{}{},
+		graphPending: map[string]int{},
+
+		events: map[string]map[string]interface{}{},
+
+		settings: map[string]interface{}{
+			"enabled":                            true,
+			"allowBlocked":                       false,
+			"allowWhenUnavailable":               false,
+			"blockUnscannedTimeoutSeconds":       60,
+			"blockUnfinishedScansTimeoutSeconds": 1800,
+			"buildRetentionDays":                 90,
+		},
+
+		dbSyncTime: "02:00",
+		dbSync: map[string]interface{}{
+			"offline_mode":               false,
+			"use_proxy":                  false,
+			"connection_timeout_seconds": 60,
+		},
+		systemParameters: map[string]interface{}{
+			"ssl_insecure":     false,
+			"maxDiskDataUsage": 80,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/system/ping", func(w http.ResponseWriter, r *http.Request) {
+		writeTestJSON(w, http.StatusOK, map[string]string{"status": "pong"})
+	})
+	mux.HandleFunc("/api/v1/policies", s.handlePolicies)
+	mux.HandleFunc("/api/v1/policies/", s.handlePolicy)
+	mux.HandleFunc("/api/v2/watches", s.handleWatches)
+	mux.HandleFunc("/api/v2/watches/", s.handleWatch)
+	mux.HandleFunc("/api/v1/test/objects", s.handleObjects)
+	mux.HandleFunc("/api/v1/test/objects/", s.handleObject)
+	mux.HandleFunc("/api/v1/violations", s.handleViolations)
+	mux.HandleFunc("/api/v1/summary/component", s.handleComponentSummary)
+	mux.HandleFunc("/api/v1/component/searchByCves", s.handleSearchByCVEs)
+	mux.HandleFunc("/api/v1/operational_risk/component", s.handleOperationalRisk)
+	mux.HandleFunc("/api/v1/summary/artifact", s.handleArtifactSummary)
+	mux.HandleFunc("/api/v1/artifact/status", s.handleArtifactStatus)
+	mux.HandleFunc("/api/v2/ci/build", s.handleBuildScan)
+	mux.HandleFunc("/api/v2/ci/build/", s.handleBuildScanResult)
+	mux.HandleFunc("/api/v1/scan/graph", s.handleGraphScan)
+	mux.HandleFunc("/api/v1/scan/graph/", s.handleGraphScanResult)
+	mux.HandleFunc("/api/v2/component/exportDetails", s.handleExport)
+	mux.HandleFunc("/api/v1/events", s.handleEvents)
+	mux.HandleFunc("/api/v1/events/", s.handleCustomEvent)
+	mux.HandleFunc("/api/v2/events/", s.handleEvent)
+	mux.HandleFunc("/api/v1/xraySettings", s.handleSettings)
+	mux.HandleFunc("/api/v1/configuration/dbsync/time", s.handleDBSyncTime)
+	mux.HandleFunc("/api/v1/configuration/dbsync", s.handleConfiguration(s.dbSync))
+	mux.HandleFunc("/api/v1/configuration/systemParameters", s.handleConfiguration(s.systemParameters))
+	mux.HandleFunc("/api/v1/updates/offline", s.handleOfflineUpdates)
+	mux.HandleFunc("/api/v1/updates/offline/", s.handleOfflineUpdate)
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// providers returns a fresh provider, so tests against different servers don't share a configured client
+func (s *testXrayServer) providers() map[string]terraform.ResourceProvider {
+	return map[string]terraform.ResourceProvider{"xray": Provider()}
+}
+
+// config prepends a provider block pointing at the server to a test configuration
+func (s *testXrayServer) config(config string) string {
+	return fmt.Sprintf(`
+provider "xray" {
+	url = "%s"
+	access_token = "test-token"
+}
+%s`, s.URL, config)
+}
+
+func (s *testXrayServer) policy(name string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.policies[name]
+}
+
+func (s *testXrayServer) watch(name string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.watches[name]
+}
+
+func (s *testXrayServer) object(id string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.objects[id]
+}
+
+// edit changes a stored object behind the provider's back, to simulate drift
+func (s *testXrayServer) edit(f func()) func() {
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		f()
+	}
+}
+
+// fastScanPolling makes the provider poll for scan results without waiting, for the duration of the test
+func fastScanPolling(t *testing.T) {
+	interval := scanPollInterval
+	scanPollInterval = time.Millisecond
+	t.Cleanup(func() { scanPollInterval = interval })
+}
+
+func (s *testXrayServer) checkDestroyed(*terraform.State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.policies) > 0 || len(s.watches) > 0 || len(s.objects) > 0 || len(s.events) > 0 {
+		return fmt.Errorf("%d policies, %d watches, %d objects and %d issues were left behind",
+			len(s.policies), len(s.watches), len(s.objects), len(s.events))
+	}
+	return nil
+}
+
+func writeTestJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeTestError(w http.ResponseWriter, status int, format string, a ...interface{}) {
+	writeTestJSON(w, status, map[string]string{"error": fmt.Sprintf(format, a...)})
+}
+
+func decodeTestBody(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	body := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return nil, false
+	}
+	return body, true
+}
+
+// storePolicy fills in the fields Xray manages itself
+func (s *testXrayServer) storePolicy(body map[string]interface{}, created string) {
+	if _, ok := body["description"]; !ok {
+		body["description"] = ""
+	}
+	body["author"] = "test-user"
+	body["created"] = created
+	body["modified"] = time.Now().UTC().Format(time.RFC3339)
+	s.policies[body["name"].(string)] = body
+}
+
+func (s *testXrayServer) handlePolicies(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		l := []interface{}{}
+		for _, p := range s.policies {
+			l = append(l, p)
+		}
+		writeTestJSON(w, http.StatusOK, l)
+	case http.MethodPost:
+		body, ok := decodeTestBody(w, r)
+		if !ok {
+			return
+		}
+		name, _ := body["name"].(string)
+		if _, exists := s.policies[name]; exists {
+			writeTestError(w, http.StatusConflict, "Policy %s already exists", name)
+			return
+		}
+		s.storePolicy(body, time.Now().UTC().Format(time.RFC3339))
+		writeTestJSON(w, http.StatusCreated, map[string]string{"info": "Policy created successfully"})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *testXrayServer) handlePolicy(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/v1/policies/")
+	existing, ok := s.policies[name]
+	if !ok {
+		writeTestError(w, http.StatusNotFound, "Failed to find Policy %s", name)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeTestJSON(w, http.StatusOK, existing)
+	case http.MethodPut:
+		body, ok := decodeTestBody(w, r)
+		if !ok {
+			return
+		}
+		// Like Xray, policies are identified by name and can't be renamed
+		if body["name"] != name {
+			writeTestError(w, http.StatusBadRequest, "Policy name %v doesn't match %s", body["name"], name)
+			return
+		}
+		s.storePolicy(body, existing["created"].(string))
+		writeTestJSON(w, http.StatusOK, map[string]string{"info": "Policy updated successfully"})
+	case http.MethodDelete:
+		// Like Xray, a policy can't be deleted while it's assigned to a watch
+		for watchName, watch := range s.watches {
+			policies, _ := watch["assigned_policies"].([]interface{})
+			for _, p := range policies {
+				if p.(map[string]interface{})["name"] == name {
+					writeTestError(w, http.StatusBadRequest, "Policy %s is assigned to watch %s", name, watchName)
+					return
+				}
+			}
+		}
+		delete(s.policies, name)
+		writeTestJSON(w, http.StatusOK, map[string]string{"info": "Policy deleted successfully"})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func testWatchName(watch map[string]interface{}) string {
+	general, _ := watch["general_data"].(map[string]interface{})
+	name, _ := general["name"].(string)
+	return name
+}
+
+func (s *testXrayServer) handleWatches(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		l := []interface{}{}
+		for _, watch := range s.watches {
+			l = append(l, watch)
+		}
+		writeTestJSON(w, http.StatusOK, l)
+	case http.MethodPost:
+		body, ok := decodeTestBody(w, r)
+		if !ok {
+			return
+		}
+		name := testWatchName(body)
+		if _, exists := s.watches[name]; exists {
+			writeTestError(w, http.StatusConflict, "Watch %s already exists", name)
+			return
+		}
+		s.watches[name] = body
+		writeTestJSON(w, http.StatusCreated, map[string]string{"info": "Watch created successfully"})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *testXrayServer) handleWatch(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/v2/watches/")
+	existing, ok := s.watches[name]
+	if !ok {
+		writeTestError(w, http.StatusNotFound, "Failed to find watch %s", name)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeTestJSON(w, http.StatusOK, existing)
+	case http.MethodPut:
+		body, ok := decodeTestBody(w, r)
+		if !ok {
+			return
+		}
+		delete(s.watches, name)
+		s.watches[testWatchName(body)] = body
+		writeTestJSON(w, http.StatusOK, map[string]string{"info": "Watch updated successfully"})
+	case http.MethodDelete:
+		delete(s.watches, name)
+		writeTestJSON(w, http.StatusOK, map[string]string{"info": "Watch deleted successfully"})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleObjects stores anything it's sent, and answers with the generated ID nested in the response
+func (s *testXrayServer) handleObjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, ok := decodeTestBody(w, r)
+	if !ok {
+		return
+	}
+	s.objectID++
+	id := strconv.Itoa(s.objectID)
+	body["created_by"] = "test-user"
+	s.objects[id] = body
+	writeTestJSON(w, http.StatusCreated, map[string]interface{}{"info": map[string]interface{}{"id": s.objectID}})
+}
+
+func (s *testXrayServer) handleObject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/test/objects/")
+	existing, ok := s.objects[id]
+	if !ok {
+		writeTestError(w, http.StatusNotFound, "Failed to find object %s", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		// Like some Xray endpoints, answer with no content rather than an empty object
+		if len(existing) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeTestJSON(w, http.StatusOK, existing)
+	case http.MethodPatch:
+		body, ok := decodeTestBody(w, r)
+		if !ok {
+			return
+		}
+		for k, v := range body {
+			existing[k] = v
+		}
+		writeTestJSON(w, http.StatusOK, map[string]string{"info": "Object updated successfully"})
+	case http.MethodDelete:
+		delete(s.objects, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleViolations supports the filters the provider sends, but only the default order of the violations
+func (s *testXrayServer) handleViolations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var req xrayViolationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+	if req.Pagination.Limit < 1 || req.Pagination.Offset < 1 {
+		writeTestError(w, http.StatusBadRequest, "invalid pagination %+v", req.Pagination)
+		return
+	}
+
+	severities := map[string]int{"": 0, "Low": 1, "Medium": 2, "High": 3, "Critical": 4}
+	matched := []map[string]interface{}{}
+	for _, v := range s.violations {
+		f := req.Filters
+		if (f.WatchName != "" && v["watch_name"] != f.WatchName) ||
+			(f.ViolationType != "" && !strings.EqualFold(v["type"].(string), f.ViolationType)) ||
+			severities[v["severity"].(string)] < severities[f.MinSeverity] {
+			continue
+		}
+		matched = append(matched, v)
+	}
+
+	start := (req.Pagination.Offset - 1) * req.Pagination.Limit
+	end := start + req.Pagination.Limit
+	if start > len(matched) {
+		start = len(matched)
+	}
+	if end > len(matched) {
+		end = len(matched)
+	}
+	writeTestJSON(w, http.StatusOK, map[string]interface{}{"total_violations": len(matched), "violations": matched[start:end]})
+}
+
+func (s *testXrayServer) handleComponentSummary(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req xrayComponentSummaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+
+	artifacts, errors := []interface{}{}, []interface{}{}
+	for _, c := range req.ComponentDetails {
+		if summary, ok := s.components[c.ComponentID]; ok {
+			artifacts = append(artifacts, summary)
+		} else {
+			errors = append(errors, map[string]string{"error": "Component not found", "identifier": c.ComponentID})
+		}
+	}
+	writeTestJSON(w, http.StatusOK, map[string]interface{}{"artifacts": artifacts, "errors": errors})
+}
+
+// handleSearchByCVEs finds the known components with an issue for each CVE, going by their summaries
+func (s *testXrayServer) handleSearchByCVEs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req xrayCVESearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+
+	packageTypes := map[string]string{}
+	for packageType, prefix := range componentIDPrefixes {
+		packageTypes[prefix] = packageType
+	}
+
+	results := []xrayCVESearchResult{}
+	for _, cve := range req.CVEs {
+		result := xrayCVESearchResult{CVE: cve, Components: []xrayCVESearchMatch{}}
+		for id, summary := range s.components {
+			for _, issue := range summary["issues"].([]interface{}) {
+				for _, c := range issue.(map[string]interface{})["cves"].([]interface{}) {
+					if c.(map[string]interface{})["cve"] != cve {
+						continue
+					}
+					parts := strings.SplitN(id, "://", 2)
+					at := strings.LastIndex(parts[1], ":")
+					result.Components = append(result.Components, xrayCVESearchMatch{
+						Name:        parts[1][:at],
+						PackageType: packageTypes[parts[0]],
+						Version:     parts[1][at+1:],
+					})
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	writeTestJSON(w, http.StatusOK, results)
+}
+
+func (s *testXrayServer) handleOperationalRisk(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req xrayOperationalRiskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+
+	components := []interface{}{}
+	for _, c := range req.Components {
+		if risk, ok := s.operationalRisks[c.ComponentID]; ok {
+			components = append(components, risk)
+		}
+	}
+	writeTestJSON(w, http.StatusOK, map[string]interface{}{"components": components})
+}
+
+func (s *testXrayServer) handleArtifactSummary(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req xrayArtifactSummaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+
+	artifacts, errors := []interface{}{}, []interface{}{}
+	for _, id := range append(req.Paths, req.Checksums...) {
+		if summary, ok := s.artifacts[id]; ok {
+			artifacts = append(artifacts, summary)
+		} else {
+			errors = append(errors, map[string]string{"error": "Artifact doesn't exist or not indexed/cached in Xray", "identifier": id})
+		}
+	}
+	writeTestJSON(w, http.StatusOK, map[string]interface{}{"artifacts": artifacts, "errors": errors})
+}
+
+func (s *testXrayServer) handleArtifactStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req xrayArtifactStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+
+	path := req.Repo + "/" + req.Path
+	statuses, ok := s.scanStatuses[path]
+	if !ok {
+		writeTestError(w, http.StatusNotFound, "Artifact %s not found", path)
+		return
+	}
+	if len(statuses) > 1 {
+		s.scanStatuses[path] = statuses[1:]
+	}
+	writeTestJSON(w, http.StatusOK, map[string]interface{}{"overall": map[string]string{"status": statuses[0]}})
+}
+
+func (s *testXrayServer) handleBuildScan(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req xrayBuildScanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+	build, ok := s.builds[req.BuildName+"/"+req.BuildNumber]
+	if !ok {
+		writeTestError(w, http.StatusNotFound, "Build %s/%s not found", req.BuildName, req.BuildNumber)
+		return
+	}
+	build.pending = build.polls
+	build.scans++
+	writeTestJSON(w, http.StatusOK, map[string]string{"info": fmt.Sprintf("Scan of Build:%s number:%s was triggered", req.BuildName, req.BuildNumber)})
+}
+
+func (s *testXrayServer) handleBuildScanResult(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v2/ci/build/")
+	build, ok := s.builds[id]
+	if !ok || build.scans == 0 {
+		writeTestError(w, http.StatusNotFound, "Build %s was not scanned", id)
+		return
+	}
+	if build.pending > 0 {
+		build.pending--
+		writeTestJSON(w, http.StatusAccepted, map[string]string{"info": "Build scan is in progress"})
+		return
+	}
+	writeTestJSON(w, http.StatusOK, build.result)
+}
+
+func (s *testXrayServer) handleGraphScan(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scan := testGraphScan{watches: r.URL.Query()["watch"], project: r.URL.Query().Get("project")}
+	if err := json.NewDecoder(r.Body).Decode(&scan.graph); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+	s.graphScans = append(s.graphScans, scan)
+	id := fmt.Sprintf("scan-%d", len(s.graphScans))
+	s.graphPending[id] = s.graphPolls
+	writeTestJSON(w, http.StatusCreated, map[string]string{"scan_id": id})
+}
+
+// handleGraphScanResult reports the issues of every component in the graph, as violations of the first watch if
+// the graph was scanned for watches
+func (s *testXrayServer) handleGraphScanResult(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/scan/graph/")
+	var n int
+	if _, err := fmt.Sscanf(id, "scan-%d", &n); err != nil || n < 1 || n > len(s.graphScans) {
+		writeTestError(w, http.StatusNotFound, "Scan %s not found", id)
+		return
+	}
+	if s.graphPending[id] > 0 {
+		s.graphPending[id]--
+		writeTestJSON(w, http.StatusAccepted, map[string]string{"info": "Scan in progress"})
+		return
+	}
+	scan := s.graphScans[n-1]
+
+	issues := []interface{}{}
+	var walk func(node *xrayGraphNode)
+	walk = func(node *xrayGraphNode) {
+		if issue, ok := s.graphIssues[node.ComponentID]; ok {
+			issue = copyTestJSON(issue)
+			if len(scan.watches) > 0 {
+				issue["watch_name"] = scan.watches[0]
+			}
+			issues = append(issues, issue)
+		}
+		for _, c := range node.Nodes {
+			walk(c)
+		}
+	}
+	walk(&scan.graph)
+
+	result := map[string]interface{}{"scan_id": id, "licenses": []interface{}{}}
+	if len(scan.watches) > 0 || scan.project != "" {
+		result["violations"] = issues
+	} else {
+		result["vulnerabilities"] = issues
+	}
+	writeTestJSON(w, http.StatusOK, result)
+}
+
+func copyTestJSON(v map[string]interface{}) map[string]interface{} {
+	c := map[string]interface{}{}
+	for k, e := range v {
+		c[k] = e
+	}
+	return c
+}
+
+// handleExport answers with a zip archive holding a minimal SBOM in the requested format
+func (s *testXrayServer) handleExport(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req xrayExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+		return
+	}
+	if _, ok := s.artifacts[req.Path]; !ok {
+		writeTestError(w, http.StatusNotFound, "Component %s not found", req.Path)
+		return
+	}
+	s.exports = append(s.exports, req)
+
+	var name, doc string
+	switch {
+	case req.CycloneDX && req.CycloneDXFormat == "json":
+		name, doc = "sbom.cdx.json", fmt.Sprintf(`{"bomFormat":"CycloneDX","metadata":{"component":{"name":%q}}}`, req.ComponentName)
+	case req.CycloneDX && req.CycloneDXFormat == "xml":
+		name, doc = "sbom.cdx.xml", fmt.Sprintf(`<bom><metadata><component><name>%s</name></component></metadata></bom>`, req.ComponentName)
+	case req.SPDX && req.SPDXFormat == "json":
+		name, doc = "sbom.spdx.json", fmt.Sprintf(`{"spdxVersion":"SPDX-2.3","name":%q}`, req.ComponentName)
+	case req.SPDX && req.SPDXFormat == "tag:value":
+		name, doc = "sbom.spdx", fmt.Sprintf("SPDXVersion: SPDX-2.3\nDocumentName: %s\n", req.ComponentName)
+	default:
+		writeTestError(w, http.StatusBadRequest, "no SBOM format requested")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	archive := zip.NewWriter(w)
+	f, _ := archive.Create(name)
+	f.Write([]byte(doc))
+	archive.Close()
+}
+
+// handleEvent finds issues by their ID only, like Xray, which doesn't look them up by CVE
+func (s *testXrayServer) handleEvent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/api/v2/events/")
+	event, ok := s.events[id]
+	if !ok {
+		writeTestError(w, http.StatusNotFound, "Issue %s not found", id)
+		return
+	}
+	writeTestJSON(w, http.StatusOK, event)
+}
+
+// storeEvent capitalises the issue type, as Xray reports it
+func (s *testXrayServer) storeEvent(id string, body map[string]interface{}) {
+	if t, ok := body["type"].(string); ok && t != "" {
+		body["type"] = strings.ToUpper(t[:1]) + strings.ToLower(t[1:])
+	}
+	body["id"] = id
+	s.events[id] = body
+}
+
+func (s *testXrayServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, ok := decodeTestBody(w, r)
+	if !ok {
+		return
+	}
+	id, _ := body["id"].(string)
+	if _, exists := s.events[id]; exists {
+		writeTestError(w, http.StatusConflict, "Issue %s already exists", id)
+		return
+	}
+	s.storeEvent(id, body)
+	writeTestJSON(w, http.StatusCreated, map[string]string{"info": "Custom issue created successfully"})
+}
+
+func (s *testXrayServer) handleCustomEvent(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/events/")
+	if _, ok := s.events[id]; !ok {
+		writeTestError(w, http.StatusNotFound, "Issue %s not found", id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		body, ok := decodeTestBody(w, r)
+		if !ok {
+			return
+		}
+		s.storeEvent(id, body)
+		writeTestJSON(w, http.StatusOK, map[string]string{"info": "Custom issue updated successfully"})
+	case http.MethodDelete:
+		delete(s.events, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleSettings only changes the settings that are sent
+func (s *testXrayServer) handleSettings(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		writeTestJSON(w, http.StatusOK, s.settings)
+	case http.MethodPut:
+		body, ok := decodeTestBody(w, r)
+		if !ok {
+			return
+		}
+		for k := range body {
+			if _, known := s.settings[k]; !known {
+				writeTestError(w, http.StatusBadRequest, "Unknown setting %s", k)
+				return
+			}
+		}
+		for k, v := range body {
+			s.settings[k] = v
+		}
+		writeTestJSON(w, http.StatusOK, map[string]string{"info": "Xray settings were updated successfully"})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *testXrayServer) handleDBSyncTime(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		writeTestJSON(w, http.StatusOK, xrayDBSyncTime{DBSyncUpdatesTime: s.dbSyncTime})
+	case http.MethodPut:
+		var body xrayDBSyncTime
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeTestError(w, http.StatusBadRequest, "invalid JSON: %s", err)
+			return
+		}
+		s.dbSyncTime = body.DBSyncUpdatesTime
+		writeTestJSON(w, http.StatusOK, map[string]string{"info": "DB sync time was updated successfully"})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleConfiguration reads and partially updates a set of configuration values, none of which are new
+func (s *testXrayServer) handleConfiguration(config map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			writeTestJSON(w, http.StatusOK, config)
+		case http.MethodPut:
+			body, ok := decodeTestBody(w, r)
+			if !ok {
+				return
+			}
+			for k := range body {
+				if _, known := config[k]; !known {
+					writeTestError(w, http.StatusBadRequest, "Unknown configuration %s", k)
+					return
+				}
+			}
+			for k, v := range body {
+				config[k] = v
+			}
+			writeTestJSON(w, http.StatusOK, map[string]string{"info": "Configuration was updated successfully"})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func (s *testXrayServer) handleOfflineUpdates(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	// Uploads don't go through the go-artifactory transports, so check they are still authenticated
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		writeTestError(w, http.StatusUnauthorized, "missing credentials")
+		return
+	}
+	bundle, err := ioutil.ReadAll(r.Body)
+	if err != nil || int64(len(bundle)) != r.ContentLength {
+		writeTestError(w, http.StatusBadRequest, "incomplete upload")
+		return
+	}
+	s.offlineUpdates = append(s.offlineUpdates, &testOfflineUpdate{bundle: bundle})
+	writeTestJSON(w, http.StatusAccepted, xrayOfflineDBUpdate{ID: strconv.Itoa(len(s.offlineUpdates)), Status: "pending"})
+}
+
+func (s *testXrayServer) handleOfflineUpdate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/v1/updates/offline/")
+	i, err := strconv.Atoi(id)
+	if err != nil || i < 1 || i > len(s.offlineUpdates) {
+		writeTestError(w, http.StatusNotFound, "Update %s not found", id)
+		return
+	}
+	update := s.offlineUpdates[i-1]
+	update.polls++
+
+	result := xrayOfflineDBUpdate{ID: id}
+	switch {
+	case update.polls < 3:
+		result.Status = "in_progress"
+	case !bytes.HasPrefix(update.bundle, []byte("PK")):
+		result.Status = "failed"
+		result.Error = "the bundle isn't a zip archive"
+	default:
+		result.Status = "completed"
+	}
+	writeTestJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,55 @@
+package jfrogxray
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/xero-oss/go-xray/xray"
+)
+
+// The getOptional helpers tell apart an attribute that was never set from one explicitly set to its zero value.
+// GetOk treats false and "" as unset, so those values would never reach the API. An attribute that is set, or that
+// changed (including being removed from the configuration), is returned. Everything else is nil so it's left out
+// of the request.
+
+func getOptionalString(d *schema.ResourceData, key string) *string {
+	if v, ok := d.GetOk(key); ok || d.HasChange(key) {
+		return xray.String(v.(string))
+	}
+	return nil
+}
+
+func getOptionalBool(d *schema.ResourceData, key string) *bool {
+	if v, ok := d.GetOkExists(key); ok || d.HasChange(key) {
+		return xray.Bool(v.(bool))
+	}
+	return nil
+}
+
+func getOptionalStringList(d *schema.ResourceData, key string) *[]string {
+	v, ok := d.GetOk(key)
+	if !ok && !d.HasChange(key) {
+		return nil
+	}
+
+	l := []string{}
+	if v != nil {
+		for _, s := range v.([]interface{}) {
+			l = append(l, s.(string))
+		}
+	}
+	return &l
+}
+
+func getOptionalInt(d *schema.ResourceData, key string) *int {
+	if v, ok := d.GetOkExists(key); ok || d.HasChange(key) {
+		return xray.Int(v.(int))
+	}
+	return nil
+}
+
+// importSingletonState imports a resource that there's only one of, whatever ID it's imported with
+func importSingletonState(id string) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		d.SetId(id)
+		return []*schema.ResourceData{d}, nil
+	}
+}
@@ -0,0 +1,50 @@
+package jfrogxray
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Values accepted by the Xray API for the various enumerated fields. Anything outside of these
+// lists is rejected by Xray with a 400, so we catch it at plan time instead.
+var (
+	validSeverities     = []string{"Low", "Medium", "High", "Critical"}
+	validExposureLevels = append([]string{"All"}, validSeverities...)
+	// Exposures and malicious packages aren't policy types of their own, they are criteria of security policies
+	validPolicyTypes    = []string{"security", "license", "operational_risk"}
+	validIssueTypes     = []string{"security", "license", "versions"}
+	validWatchResources = []string{"repository", "all-repos", "build", "all-builds", "project", "release-bundle"}
+	validWatchFilters   = []string{"regex", "package-type", "path-regex", "ant-patterns", "mime-type"}
+	validPackageTypes   = []string{"alpine", "cargo", "cocoapods", "composer", "conan", "conda", "cran", "debian", "docker", "gems", "generic", "go", "huggingface", "maven", "npm", "nuget", "pypi", "rpm", "terraform"}
+)
+
+// Xray normalizes severities to title case ("high" comes back as "High"), so they are validated
+// without regard to case and the resulting diff is suppressed
+var validateSeverity = validation.StringInSlice(validSeverities, true)
+
+var validateExposureSeverity = validation.StringInSlice(validExposureLevels, true)
+
+var validatePolicyType = validation.StringInSlice(validPolicyTypes, false)
+
+var validateIssueType = validation.StringInSlice(validIssueTypes, true)
+
+var validatePackageType = validation.StringInSlice(validPackageTypes, true)
+
+// Xray only knows about CVEs and its own XRAY-<n> issue IDs
+var validateVulnerabilityID = validation.StringMatch(
+	regexp.MustCompile(`^(CVE-\d{4}-\d{4,}|XRAY-\d+)$`),
+	"must be a CVE ID (CVE-2021-44228) or an Xray issue ID (XRAY-123456)",
+)
+
+// Package versions are either a single version or a range in Maven notation, e.g. "[1.0,2.0)" or "(,2.17.0)"
+var validatePackageVersion = validation.StringMatch(
+	regexp.MustCompile(`^([^\[\](),\s]+|[\[(][^\[\](),\s]*,[^\[\](),\s]*[\])])$`),
+	"must be a version or a version range such as \"[1.0,2.0)\" or \"(,2.17.0)\"",
+)
+
+func suppressCaseInsensitiveDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
@@ -0,0 +1,67 @@
+package jfrogxray
+
+import (
+	"testing"
+)
+
+func TestValidateSeverity(t *testing.T) {
+	for _, v := range []string{"Low", "medium", "HIGH", "Critical"} {
+		if _, errs := validateSeverity(v, "min_severity"); len(errs) > 0 {
+			t.Errorf("expected %q to be a valid severity, got %v", v, errs)
+		}
+	}
+	for _, v := range []string{"", "Severe", "Hi"} {
+		if _, errs := validateSeverity(v, "min_severity"); len(errs) == 0 {
+			t.Errorf("expected %q to be an invalid severity", v)
+		}
+	}
+}
+
+func TestValidatePolicyType(t *testing.T) {
+	for _, v := range []string{"security", "license", "operational_risk"} {
+		if _, errs := validatePolicyType(v, "type"); len(errs) > 0 {
+			t.Errorf("expected %q to be a valid policy type, got %v", v, errs)
+		}
+	}
+	// Xray does not normalize the case of the policy type
+	for _, v := range []string{"Security", "licence", "operational-risk"} {
+		if _, errs := validatePolicyType(v, "type"); len(errs) == 0 {
+			t.Errorf("expected %q to be an invalid policy type", v)
+		}
+	}
+}
+
+func TestValidateVulnerabilityID(t *testing.T) {
+	for _, v := range []string{"CVE-2021-44228", "CVE-2014-0160", "XRAY-123456"} {
+		if _, errs := validateVulnerabilityID(v, "vulnerability_ids.0"); len(errs) > 0 {
+			t.Errorf("expected %q to be a valid vulnerability ID, got %v", v, errs)
+		}
+	}
+	for _, v := range []string{"", "CVE-21-44228", "cve-2021-44228", "XRAY-", "GHSA-jfh8-c2jp-5v3q"} {
+		if _, errs := validateVulnerabilityID(v, "vulnerability_ids.0"); len(errs) == 0 {
+			t.Errorf("expected %q to be an invalid vulnerability ID", v)
+		}
+	}
+}
+
+func TestValidatePackageVersion(t *testing.T) {
+	for _, v := range []string{"2.17.0", "(,2.17.0)", "[1.0,2.0)", "[2.0,)"} {
+		if _, errs := validatePackageVersion(v, "package_versions.0"); len(errs) > 0 {
+			t.Errorf("expected %q to be a valid package version, got %v", v, errs)
+		}
+	}
+	for _, v := range []string{"", "< 2.17.0", "(2.17.0", "[1.0,2.0,3.0]"} {
+		if _, errs := validatePackageVersion(v, "package_versions.0"); len(errs) == 0 {
+			t.Errorf("expected %q to be an invalid package version", v)
+		}
+	}
+}
+
+func TestSuppressCaseInsensitiveDiff(t *testing.T) {
+	if !suppressCaseInsensitiveDiff("min_severity", "High", "high", nil) {
+		t.Error("expected a diff between High and high to be suppressed")
+	}
+	if suppressCaseInsensitiveDiff("min_severity", "High", "Low", nil) {
+		t.Error("expected a diff between High and Low not to be suppressed")
+	}
+}